@@ -4,15 +4,46 @@ import (
 	scanner2 "github.com/mburbidg/cypher/scanner"
 )
 
+// Node is implemented by every node of the AST.
 type Node interface {
+	// Span returns the source range the node was parsed from.
+	Span() Span
 }
 
-type Query interface {
+// Span is the source range [Start, End) that a node was parsed from.
+type Span = scanner2.Span
+
+// NodeSpan is embedded in every node to record its span.
+type NodeSpan struct {
+	span Span
+}
+
+func (n *NodeSpan) Span() Span {
+	return n.span
+}
+
+// SetSpan records the span of the node. It is called by the parser.
+func (n *NodeSpan) SetSpan(span Span) {
+	n.span = span
+}
+
+// Statement is either a query or a schema command.
+type Statement interface {
 	Node
 	Acceptor
+	statementNode()
+}
+
+type Query interface {
+	Statement
 	queryNode()
 }
 
+type SchemaCommand interface {
+	Statement
+	schemaCommandNode()
+}
+
 type ReadingClause interface {
 	Node
 	Acceptor
@@ -25,7 +56,45 @@ type UpdatingClause interface {
 	updatingClauseNode()
 }
 
+type UnionQuery struct {
+	NodeSpan
+	Query  Query
+	Unions []*Union
+}
+
+func (q *UnionQuery) Accept(visitor Visitor) error {
+	if err := visitor.VisitUnionQueryEnter(q); err != nil {
+		return err
+	}
+	if err := q.Query.Accept(visitor); err != nil {
+		return err
+	}
+	for _, union := range q.Unions {
+		if err := union.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitUnionQueryLeave(q)
+}
+
+type Union struct {
+	NodeSpan
+	All   bool
+	Query Query
+}
+
+func (u *Union) Accept(visitor Visitor) error {
+	if err := visitor.VisitUnionEnter(u); err != nil {
+		return err
+	}
+	if err := u.Query.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitUnionLeave(u)
+}
+
 type SinglePartQuery struct {
+	NodeSpan
 	ReadingClause  []ReadingClause
 	UpdatingClause []UpdatingClause
 	*Projection
@@ -45,50 +114,687 @@ func (q *SinglePartQuery) Accept(visitor Visitor) error {
 			return err
 		}
 	}
-	if q.Projection != nil {
-		if err := q.Projection.Accept(visitor); err != nil {
+	if q.Projection != nil {
+		if err := q.Projection.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSinglePartQueryLeave(q)
+}
+
+type StandaloneCall struct {
+	NodeSpan
+	Procedure  *ProcedureInvocation
+	YieldAll   bool
+	YieldItems *YieldItems
+}
+
+func (c *StandaloneCall) Accept(visitor Visitor) error {
+	if err := visitor.VisitStandaloneCallEnter(c); err != nil {
+		return err
+	}
+	if err := c.Procedure.Accept(visitor); err != nil {
+		return err
+	}
+	if c.YieldItems != nil {
+		if err := c.YieldItems.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitStandaloneCallLeave(c)
+}
+
+type MultiPartQuery struct {
+	NodeSpan
+	Parts           []*MultiPartQueryPart
+	SinglePartQuery *SinglePartQuery
+}
+
+func (q *MultiPartQuery) Accept(visitor Visitor) error {
+	if err := visitor.VisitMultiPartQueryEnter(q); err != nil {
+		return err
+	}
+	for _, part := range q.Parts {
+		if err := part.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := q.SinglePartQuery.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitMultiPartQueryLeave(q)
+}
+
+type MultiPartQueryPart struct {
+	NodeSpan
+	ReadingClause  []ReadingClause
+	UpdatingClause []UpdatingClause
+	With           *WithClause
+}
+
+func (part *MultiPartQueryPart) Accept(visitor Visitor) error {
+	if err := visitor.VisitMultiPartQueryPartEnter(part); err != nil {
+		return err
+	}
+	for _, clause := range part.ReadingClause {
+		if err := clause.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	for _, clause := range part.UpdatingClause {
+		if err := clause.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := part.With.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitMultiPartQueryPartLeave(part)
+}
+
+type WithClause struct {
+	NodeSpan
+	Projection *Projection
+	WhereExpr  Expr
+}
+
+func (w *WithClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitWithEnter(w); err != nil {
+		return err
+	}
+	if err := w.Projection.Accept(visitor); err != nil {
+		return err
+	}
+	if w.WhereExpr != nil {
+		if err := w.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitWithLeave(w)
+}
+
+type CreateClause struct {
+	NodeSpan
+	Pattern *Pattern
+}
+
+func (c *CreateClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitCreateEnter(c); err != nil {
+		return err
+	}
+	if err := c.Pattern.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitCreateLeave(c)
+}
+
+type InQueryCall struct {
+	NodeSpan
+	Procedure  *ProcedureInvocation
+	YieldItems *YieldItems
+}
+
+func (c *InQueryCall) Accept(visitor Visitor) error {
+	if err := visitor.VisitInQueryCallEnter(c); err != nil {
+		return err
+	}
+	if err := c.Procedure.Accept(visitor); err != nil {
+		return err
+	}
+	if c.YieldItems != nil {
+		if err := c.YieldItems.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitInQueryCallLeave(c)
+}
+
+// SubqueryCall is CALL { ... }, optionally run IN TRANSACTIONS. BatchSize is the number of rows given by
+// OF n ROWS, and is nil when omitted.
+type SubqueryCall struct {
+	NodeSpan
+	Query          Query
+	InTransactions bool
+	BatchSize      Expr
+}
+
+func (c *SubqueryCall) Accept(visitor Visitor) error {
+	if err := visitor.VisitSubqueryCallEnter(c); err != nil {
+		return err
+	}
+	if err := c.Query.Accept(visitor); err != nil {
+		return err
+	}
+	if c.BatchSize != nil {
+		if err := c.BatchSize.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSubqueryCallLeave(c)
+}
+
+type ProcedureInvocation struct {
+	NodeSpan
+	ProcedureName *SymbolicFunctionName
+	Implicit      bool
+	Args          []Expr
+}
+
+func (inv *ProcedureInvocation) Accept(visitor Visitor) error {
+	if err := visitor.VisitProcedureInvocationEnter(inv); err != nil {
+		return err
+	}
+	if err := inv.ProcedureName.Accept(visitor); err != nil {
+		return err
+	}
+	for _, arg := range inv.Args {
+		if err := arg.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitProcedureInvocationLeave(inv)
+}
+
+type YieldItems struct {
+	NodeSpan
+	Items     []*YieldItem
+	WhereExpr Expr
+}
+
+func (items *YieldItems) Accept(visitor Visitor) error {
+	if err := visitor.VisitYieldItemsEnter(items); err != nil {
+		return err
+	}
+	for _, item := range items.Items {
+		if err := item.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if items.WhereExpr != nil {
+		if err := items.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitYieldItemsLeave(items)
+}
+
+type YieldItem struct {
+	NodeSpan
+	Field    SymbolicName
+	Variable SymbolicName
+}
+
+func (item *YieldItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitYieldItemEnter(item); err != nil {
+		return err
+	}
+	if item.Field != nil {
+		if err := item.Field.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := item.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitYieldItemLeave(item)
+}
+
+type MergeClause struct {
+	NodeSpan
+	PatternPart *PatternPart
+	Actions     []*MergeAction
+}
+
+func (m *MergeClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitMergeEnter(m); err != nil {
+		return err
+	}
+	if err := m.PatternPart.Accept(visitor); err != nil {
+		return err
+	}
+	for _, action := range m.Actions {
+		if err := action.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitMergeLeave(m)
+}
+
+type MergeAction struct {
+	NodeSpan
+	Type MergeActionType
+	Set  *SetClause
+}
+
+func (action *MergeAction) Accept(visitor Visitor) error {
+	if err := visitor.VisitMergeActionEnter(action); err != nil {
+		return err
+	}
+	if err := action.Set.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitMergeActionLeave(action)
+}
+
+type ForeachClause struct {
+	NodeSpan
+	Variable        SymbolicName
+	Expr            Expr
+	UpdatingClauses []UpdatingClause
+}
+
+func (f *ForeachClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitForeachEnter(f); err != nil {
+		return err
+	}
+	if err := f.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	if err := f.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	for _, clause := range f.UpdatingClauses {
+		if err := clause.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitForeachLeave(f)
+}
+
+type SetClause struct {
+	NodeSpan
+	Items []SetItem
+}
+
+func (s *SetClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitSetEnter(s); err != nil {
+		return err
+	}
+	for _, item := range s.Items {
+		if err := item.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitSetLeave(s)
+}
+
+type SetItem interface {
+	Node
+	Acceptor
+	setItemNode()
+}
+
+type PropertySetItem struct {
+	NodeSpan
+	Property Expr
+	Expr     Expr
+}
+
+func (item *PropertySetItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitPropertySetItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Property.Accept(visitor); err != nil {
+		return err
+	}
+	if err := item.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitPropertySetItemLeave(item)
+}
+
+type MatchClause struct {
+	NodeSpan
+	Optional  bool
+	Pattern   *Pattern
+	WhereExpr Expr
+}
+
+func (m *MatchClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitMatchEnter(m); err != nil {
+		return err
+	}
+	if err := m.Pattern.Accept(visitor); err != nil {
+		return err
+	}
+	if m.WhereExpr != nil {
+		if err := m.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitMatchLeave(m)
+}
+
+type UnwindClause struct {
+	NodeSpan
+	Expr     Expr
+	Variable SymbolicName
+}
+
+func (u *UnwindClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitUnwindEnter(u); err != nil {
+		return err
+	}
+	if err := u.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	if err := u.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitUnwindLeave(u)
+}
+
+// LoadCSVClause is LOAD CSV. FieldTerminator is empty when no FIELDTERMINATOR is given.
+type LoadCSVClause struct {
+	NodeSpan
+	WithHeaders     bool
+	URL             Expr
+	Variable        SymbolicName
+	FieldTerminator string
+}
+
+func (l *LoadCSVClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitLoadCSVEnter(l); err != nil {
+		return err
+	}
+	if err := l.URL.Accept(visitor); err != nil {
+		return err
+	}
+	if err := l.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLoadCSVLeave(l)
+}
+
+type VariableSetItem struct {
+	NodeSpan
+	Variable SymbolicName
+	Expr     Expr
+}
+
+func (item *VariableSetItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitVariableSetItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	if err := item.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitVariableSetItemLeave(item)
+}
+
+type VariableAddSetItem struct {
+	NodeSpan
+	Variable SymbolicName
+	Expr     Expr
+}
+
+func (item *VariableAddSetItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitVariableAddSetItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	if err := item.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitVariableAddSetItemLeave(item)
+}
+
+type LabelsSetItem struct {
+	NodeSpan
+	Variable SymbolicName
+	Labels   []SchemaName
+}
+
+func (item *LabelsSetItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelsSetItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	for _, label := range item.Labels {
+		if err := label.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitLabelsSetItemLeave(item)
+}
+
+type RemoveClause struct {
+	NodeSpan
+	Items []RemoveItem
+}
+
+func (r *RemoveClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitRemoveEnter(r); err != nil {
+		return err
+	}
+	for _, item := range r.Items {
+		if err := item.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitRemoveLeave(r)
+}
+
+type RemoveItem interface {
+	Node
+	Acceptor
+	removeItemNode()
+}
+
+type LabelsRemoveItem struct {
+	NodeSpan
+	Variable SymbolicName
+	Labels   []SchemaName
+}
+
+func (item *LabelsRemoveItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelsRemoveItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	for _, label := range item.Labels {
+		if err := label.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitLabelsRemoveItemLeave(item)
+}
+
+type PropertyRemoveItem struct {
+	NodeSpan
+	Property Expr
+}
+
+func (item *PropertyRemoveItem) Accept(visitor Visitor) error {
+	if err := visitor.VisitPropertyRemoveItemEnter(item); err != nil {
+		return err
+	}
+	if err := item.Property.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitPropertyRemoveItemLeave(item)
+}
+
+type DeleteClause struct {
+	NodeSpan
+	Detach bool
+	Exprs  []Expr
+}
+
+func (d *DeleteClause) Accept(visitor Visitor) error {
+	if err := visitor.VisitDeleteEnter(d); err != nil {
+		return err
+	}
+	for _, expr := range d.Exprs {
+		if err := expr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDeleteLeave(d)
+}
+
+type CreateIndex struct {
+	NodeSpan
+	Name        SymbolicName
+	IfNotExists bool
+	Index       *IndexDefinition
+}
+
+func (c *CreateIndex) Accept(visitor Visitor) error {
+	if err := visitor.VisitCreateIndexEnter(c); err != nil {
+		return err
+	}
+	if c.Name != nil {
+		if err := c.Name.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := c.Index.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitCreateIndexLeave(c)
+}
+
+// DropIndex drops an index either by name or, when Name is nil, by its definition.
+type DropIndex struct {
+	NodeSpan
+	Name     SymbolicName
+	IfExists bool
+	Index    *IndexDefinition
+}
+
+func (d *DropIndex) Accept(visitor Visitor) error {
+	if err := visitor.VisitDropIndexEnter(d); err != nil {
+		return err
+	}
+	if d.Name != nil {
+		if err := d.Name.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if d.Index != nil {
+		if err := d.Index.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDropIndexLeave(d)
+}
+
+// IndexDefinition is the label or relationship type, and the properties, that an index covers. Variable is
+// nil for the ON :Label(property) form.
+type IndexDefinition struct {
+	NodeSpan
+	Variable     SymbolicName
+	Label        SchemaName
+	Relationship bool
+	Properties   []SchemaName
+}
+
+func (def *IndexDefinition) Accept(visitor Visitor) error {
+	if err := visitor.VisitIndexDefinitionEnter(def); err != nil {
+		return err
+	}
+	if def.Variable != nil {
+		if err := def.Variable.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if err := def.Label.Accept(visitor); err != nil {
+		return err
+	}
+	for _, property := range def.Properties {
+		if err := property.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitIndexDefinitionLeave(def)
+}
+
+type CreateConstraint struct {
+	NodeSpan
+	Name        SymbolicName
+	IfNotExists bool
+	Constraint  *ConstraintDefinition
+}
+
+func (c *CreateConstraint) Accept(visitor Visitor) error {
+	if err := visitor.VisitCreateConstraintEnter(c); err != nil {
+		return err
+	}
+	if c.Name != nil {
+		if err := c.Name.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitSinglePartQueryLeave(q)
+	if err := c.Constraint.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitCreateConstraintLeave(c)
 }
 
-type CreateClause struct {
-	Pattern *Pattern
+// DropConstraint drops a constraint either by name or, when Name is nil, by its definition.
+type DropConstraint struct {
+	NodeSpan
+	Name       SymbolicName
+	IfExists   bool
+	Constraint *ConstraintDefinition
 }
 
-func (c *CreateClause) Accept(visitor Visitor) error {
-	if err := visitor.VisitCreateEnter(c); err != nil {
+func (d *DropConstraint) Accept(visitor Visitor) error {
+	if err := visitor.VisitDropConstraintEnter(d); err != nil {
 		return err
 	}
-	if err := c.Pattern.Accept(visitor); err != nil {
-		return err
+	if d.Name != nil {
+		if err := d.Name.Accept(visitor); err != nil {
+			return err
+		}
 	}
-	return visitor.VisitCreateLeave(c)
+	if d.Constraint != nil {
+		if err := d.Constraint.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitDropConstraintLeave(d)
 }
 
-type MatchClause struct {
-	Optional  bool
-	Pattern   *Pattern
-	WhereExpr Expr
+type ConstraintDefinition struct {
+	NodeSpan
+	Variable     SymbolicName
+	Label        SchemaName
+	Relationship bool
+	Type         ConstraintType
+	Properties   []SchemaName
 }
 
-func (m *MatchClause) Accept(visitor Visitor) error {
-	if err := visitor.VisitMatchEnter(m); err != nil {
+func (def *ConstraintDefinition) Accept(visitor Visitor) error {
+	if err := visitor.VisitConstraintDefinitionEnter(def); err != nil {
 		return err
 	}
-	if err := m.Pattern.Accept(visitor); err != nil {
+	if err := def.Variable.Accept(visitor); err != nil {
 		return err
 	}
-	if m.WhereExpr != nil {
-		if err := m.WhereExpr.Accept(visitor); err != nil {
+	if err := def.Label.Accept(visitor); err != nil {
+		return err
+	}
+	for _, property := range def.Properties {
+		if err := property.Accept(visitor); err != nil {
 			return err
 		}
 	}
-	return visitor.VisitMatchLeave(m)
+	return visitor.VisitConstraintDefinitionLeave(def)
 }
 
 type Pattern struct {
+	NodeSpan
 	Parts []*PatternPart
 }
 
@@ -105,12 +811,18 @@ func (p *Pattern) Accept(visitor Visitor) error {
 }
 
 type PatternElement interface {
+	Node
 	Acceptor
 	patternElementNode()
 }
 
+// PatternElementNested is a parenthesized path pattern. With a Quantifier it is a quantified path pattern,
+// ((a)-[:R]->(b)){1,5}, whose Begin and End are the minimum and maximum number of repetitions. The '+' and '*'
+// quantifiers are {1,} and {0,}, and an absent maximum is math.MaxInt64.
 type PatternElementNested struct {
-	Element PatternElement
+	NodeSpan
+	Element    PatternElement
+	Quantifier *RangeLiteral
 }
 
 func (p *PatternElementNested) Accept(visitor Visitor) error {
@@ -120,10 +832,35 @@ func (p *PatternElementNested) Accept(visitor Visitor) error {
 	if err := p.Element.Accept(visitor); err != nil {
 		return err
 	}
+	if p.Quantifier != nil {
+		if err := p.Quantifier.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitPatternElementNestedLeave(p)
 }
 
+// PatternElementSequence is a path pattern made of juxtaposed elements, at least one of which is a
+// parenthesized path pattern, as in (a) ((b)-[:R]->(c))+ (d).
+type PatternElementSequence struct {
+	NodeSpan
+	Elements []PatternElement
+}
+
+func (p *PatternElementSequence) Accept(visitor Visitor) error {
+	if err := visitor.VisitPatternElementSequenceEnter(p); err != nil {
+		return err
+	}
+	for _, element := range p.Elements {
+		if err := element.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitPatternElementSequenceLeave(p)
+}
+
 type PatternElementPattern struct {
+	NodeSpan
 	Left  *NodePattern
 	Chain []*PatternElementChain
 }
@@ -143,8 +880,28 @@ func (p *PatternElementPattern) Accept(visitor Visitor) error {
 	return visitor.VisitPatternElementPatternLeave(p)
 }
 
+// ShortestPathPattern is shortestPath(...) or, when All is true, allShortestPaths(...). It may be used both as
+// a pattern element and as an expression.
+type ShortestPathPattern struct {
+	NodeSpan
+	All     bool
+	Element PatternElement
+}
+
+func (p *ShortestPathPattern) Accept(visitor Visitor) error {
+	if err := visitor.VisitShortestPathPatternEnter(p); err != nil {
+		return err
+	}
+	if err := p.Element.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitShortestPathPatternLeave(p)
+}
+
 type PatternPart struct {
+	NodeSpan
 	Variable SymbolicName
+	Selector *PathSelector
 	Element  PatternElement
 }
 
@@ -157,13 +914,31 @@ func (part *PatternPart) Accept(visitor Visitor) error {
 			return err
 		}
 	}
+	if part.Selector != nil {
+		if err := part.Selector.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if err := part.Element.Accept(visitor); err != nil {
 		return err
 	}
 	return visitor.VisitPatternPartLeave(part)
 }
 
+// PathSelector selects among the paths matched by a pattern part: ANY SHORTEST, ALL SHORTEST or SHORTEST k,
+// where Count is k.
+type PathSelector struct {
+	NodeSpan
+	Kind  PathSelectorKind
+	Count int64
+}
+
+func (selector *PathSelector) Accept(visitor Visitor) error {
+	return visitor.VisitPathSelector(selector)
+}
+
 type Projection struct {
+	NodeSpan
 	Distinct bool
 	Items    *ProjectionItems
 	Order    *SortOrder
@@ -190,6 +965,7 @@ func (p *Projection) Accept(visitor Visitor) error {
 }
 
 type ProjectionItems struct {
+	NodeSpan
 	All   bool
 	Items []*ProjectionItem
 }
@@ -207,6 +983,7 @@ func (p *ProjectionItems) Accept(visitor Visitor) error {
 }
 
 type ProjectionItem struct {
+	NodeSpan
 	Expr     Expr
 	Variable SymbolicName
 }
@@ -227,6 +1004,7 @@ func (p *ProjectionItem) Accept(visitor Visitor) error {
 }
 
 type SortOrder struct {
+	NodeSpan
 	Items []*SortItem
 }
 
@@ -243,6 +1021,7 @@ func (o *SortOrder) Accept(visitor Visitor) error {
 }
 
 type SortItem struct {
+	NodeSpan
 	Expr  Expr
 	Order Order
 }
@@ -264,6 +1043,7 @@ type Expr interface {
 }
 
 type OpExpr struct {
+	NodeSpan
 	Op Operator
 }
 
@@ -271,7 +1051,78 @@ func (expr *OpExpr) Accept(visitor Visitor) error {
 	return visitor.VisitOpExpr(expr)
 }
 
+// TypePredicateExpr is the type predicate of expr IS :: TYPE, or of IS NOT :: TYPE when Not is true. Like
+// the OpExpr of IS NULL, it follows the expression it tests in a StringOrListOp.
+type TypePredicateExpr struct {
+	NodeSpan
+	Not  bool
+	Type CypherType
+}
+
+func (expr *TypePredicateExpr) Accept(visitor Visitor) error {
+	if err := visitor.VisitTypePredicateExprEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Type.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitTypePredicateExprLeave(expr)
+}
+
+// CypherType is a type of the Cypher type system, such as INTEGER, STRING NOT NULL or LIST<INTEGER | FLOAT>.
+type CypherType interface {
+	Node
+	Acceptor
+	cypherTypeNode()
+}
+
+type SimpleType struct {
+	NodeSpan
+	Type    ValueType
+	NotNull bool
+}
+
+func (t *SimpleType) Accept(visitor Visitor) error {
+	return visitor.VisitSimpleType(t)
+}
+
+type ListType struct {
+	NodeSpan
+	Element CypherType
+	NotNull bool
+}
+
+func (t *ListType) Accept(visitor Visitor) error {
+	if err := visitor.VisitListTypeEnter(t); err != nil {
+		return err
+	}
+	if err := t.Element.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitListTypeLeave(t)
+}
+
+// UnionType is INTEGER | STRING or, with NotNull possible, ANY<INTEGER | STRING>.
+type UnionType struct {
+	NodeSpan
+	Types   []CypherType
+	NotNull bool
+}
+
+func (t *UnionType) Accept(visitor Visitor) error {
+	if err := visitor.VisitUnionTypeEnter(t); err != nil {
+		return err
+	}
+	for _, member := range t.Types {
+		if err := member.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitUnionTypeLeave(t)
+}
+
 type UnaryExpr struct {
+	NodeSpan
 	Op   Operator
 	Expr Expr
 }
@@ -287,6 +1138,7 @@ func (expr *UnaryExpr) Accept(visitor Visitor) error {
 }
 
 type BinaryExpr struct {
+	NodeSpan
 	Left  Expr
 	Op    Operator
 	Right Expr
@@ -306,6 +1158,7 @@ func (expr *BinaryExpr) Accept(visitor Visitor) error {
 }
 
 type TernaryExpr struct {
+	NodeSpan
 	E1 Expr
 	Op Operator
 	E2 Expr
@@ -329,6 +1182,7 @@ func (expr *TernaryExpr) Accept(visitor Visitor) error {
 }
 
 type ListExpr struct {
+	NodeSpan
 	List []Expr
 }
 
@@ -345,6 +1199,7 @@ func (expr *ListExpr) Accept(visitor Visitor) error {
 }
 
 type ListComprehensionExpr struct {
+	NodeSpan
 	FilterExpr Expr
 	Expr       Expr
 }
@@ -362,10 +1217,14 @@ func (expr *ListComprehensionExpr) Accept(visitor Visitor) error {
 	return visitor.VisitListComprehensionExprLeave(expr)
 }
 
+// PropertyLabelsExpr is an atom followed by property lookups and a label predicate. A predicate of simple
+// labels, n:A:B, is held in Labels, any other label expression is held in LabelExpr.
 type PropertyLabelsExpr struct {
+	NodeSpan
 	Atom         Expr
 	PropertyKeys []SchemaName
 	Labels       []SchemaName
+	LabelExpr    LabelExpr
 }
 
 func (expr *PropertyLabelsExpr) Accept(visitor Visitor) error {
@@ -385,15 +1244,22 @@ func (expr *PropertyLabelsExpr) Accept(visitor Visitor) error {
 			return err
 		}
 	}
+	if expr.LabelExpr != nil {
+		if err := expr.LabelExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitPropertyLabelsExprLeave(expr)
 }
 
 type SchemaName interface {
+	Node
 	Acceptor
 	schemaNameNode()
 }
 
 type SymbolicNameSchemaName struct {
+	NodeSpan
 	SymbolicName SymbolicName
 }
 
@@ -408,6 +1274,7 @@ func (name *SymbolicNameSchemaName) Accept(visitor Visitor) error {
 }
 
 type ReservedWordSchemaName struct {
+	NodeSpan
 	TokenType scanner2.TokenType
 }
 
@@ -415,12 +1282,99 @@ func (name *ReservedWordSchemaName) Accept(visitor Visitor) error {
 	return visitor.VisitReservedWordSchemaName(name)
 }
 
+// LabelExpr is a label expression, such as A&(B|!C) or %, in a node pattern, relationship pattern or
+// label predicate.
+type LabelExpr interface {
+	Node
+	Acceptor
+	labelExprNode()
+}
+
+type LabelName struct {
+	NodeSpan
+	Name SchemaName
+}
+
+func (expr *LabelName) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelNameEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Name.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLabelNameLeave(expr)
+}
+
+// LabelWildcard is %, which matches any label.
+type LabelWildcard struct {
+	NodeSpan
+}
+
+func (expr *LabelWildcard) Accept(visitor Visitor) error {
+	return visitor.VisitLabelWildcard(expr)
+}
+
+type LabelNot struct {
+	NodeSpan
+	Expr LabelExpr
+}
+
+func (expr *LabelNot) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelNotEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLabelNotLeave(expr)
+}
+
+type LabelAnd struct {
+	NodeSpan
+	Left  LabelExpr
+	Right LabelExpr
+}
+
+func (expr *LabelAnd) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelAndEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Left.Accept(visitor); err != nil {
+		return err
+	}
+	if err := expr.Right.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLabelAndLeave(expr)
+}
+
+type LabelOr struct {
+	NodeSpan
+	Left  LabelExpr
+	Right LabelExpr
+}
+
+func (expr *LabelOr) Accept(visitor Visitor) error {
+	if err := visitor.VisitLabelOrEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Left.Accept(visitor); err != nil {
+		return err
+	}
+	if err := expr.Right.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLabelOrLeave(expr)
+}
+
 type SymbolicName interface {
+	Node
 	Acceptor
 	symbolicNameNode()
 }
 
 type SymbolicNameIdentifier struct {
+	NodeSpan
 	Identifier scanner2.Token
 	Type       SymbolType
 }
@@ -430,6 +1384,7 @@ func (name *SymbolicNameIdentifier) Accept(visitor Visitor) error {
 }
 
 type SymbolicNameHexLetter struct {
+	NodeSpan
 	Letter rune
 }
 
@@ -438,6 +1393,7 @@ func (name *SymbolicNameHexLetter) Accept(visitor Visitor) error {
 }
 
 type ReservedWord struct {
+	NodeSpan
 	Token scanner2.Token
 }
 
@@ -446,6 +1402,7 @@ func (word *ReservedWord) Accept(visitor Visitor) error {
 }
 
 type Label struct {
+	NodeSpan
 }
 
 func (label *Label) Accept(visitor Visitor) error {
@@ -453,6 +1410,7 @@ func (label *Label) Accept(visitor Visitor) error {
 }
 
 type PrimitiveLiteral struct {
+	NodeSpan
 	Kind  scanner2.TokenType
 	Value interface{}
 }
@@ -462,6 +1420,7 @@ func (literal *PrimitiveLiteral) Accept(visitor Visitor) error {
 }
 
 type ListLiteral struct {
+	NodeSpan
 	Items []Expr
 }
 
@@ -478,6 +1437,7 @@ func (literal *ListLiteral) Accept(visitor Visitor) error {
 }
 
 type Parameter struct {
+	NodeSpan
 	SymbolicName SymbolicName
 	N            *scanner2.Token
 }
@@ -493,6 +1453,7 @@ func (param *Parameter) Accept(visitor Visitor) error {
 }
 
 type CaseExpr struct {
+	NodeSpan
 	Init         Expr
 	Alternatives []*CaseAltNode
 	Else         Expr
@@ -517,6 +1478,7 @@ func (expr *CaseExpr) Accept(visitor Visitor) error {
 }
 
 type CaseAltNode struct {
+	NodeSpan
 	When Expr
 	Then Expr
 }
@@ -535,6 +1497,7 @@ func (alt *CaseAltNode) Accept(visitor Visitor) error {
 }
 
 type QuantifierExpr struct {
+	NodeSpan
 	Op   Operator
 	Expr Expr
 }
@@ -550,6 +1513,7 @@ func (quantifier *QuantifierExpr) Accept(visitor Visitor) error {
 }
 
 type FilterExpr struct {
+	NodeSpan
 	Variable  SymbolicName
 	InExpr    Expr
 	WhereExpr Expr
@@ -565,13 +1529,16 @@ func (filter *FilterExpr) Accept(visitor Visitor) error {
 	if err := filter.InExpr.Accept(visitor); err != nil {
 		return err
 	}
-	if err := filter.WhereExpr.Accept(visitor); err != nil {
-		return err
+	if filter.WhereExpr != nil {
+		if err := filter.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
 	}
 	return visitor.VisitFilterExprLeave(filter)
 }
 
 type VariableExpr struct {
+	NodeSpan
 	SymbolicName SymbolicName
 }
 
@@ -586,6 +1553,7 @@ func (expr *VariableExpr) Accept(visitor Visitor) error {
 }
 
 type PatternComprehensionExpr struct {
+	NodeSpan
 	Variable            SymbolicName
 	ReltionshipsPattern Expr
 	WhereExpr           Expr
@@ -611,10 +1579,15 @@ func (expr *PatternComprehensionExpr) Accept(visitor Visitor) error {
 	return visitor.VisitPatternComprehensionExprLeave(expr)
 }
 
+// NodePattern holds simple labels, (n:A:B), in Labels and any other label expression in LabelExpr. WhereExpr
+// is the inline predicate of (n WHERE n.age > 30).
 type NodePattern struct {
+	NodeSpan
 	Variable   SymbolicName
 	Labels     []SchemaName
+	LabelExpr  LabelExpr
 	Properties *Properties
+	WhereExpr  Expr
 }
 
 func (pattern *NodePattern) Accept(visitor Visitor) error {
@@ -631,15 +1604,26 @@ func (pattern *NodePattern) Accept(visitor Visitor) error {
 			return err
 		}
 	}
+	if pattern.LabelExpr != nil {
+		if err := pattern.LabelExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if pattern.Properties != nil {
 		if err := pattern.Properties.Accept(visitor); err != nil {
 			return err
 		}
 	}
+	if pattern.WhereExpr != nil {
+		if err := pattern.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitNodePatternLeave(pattern)
 }
 
 type MapLiteral struct {
+	NodeSpan
 	PropertyKeyNames []*PropertyKeyName
 }
 
@@ -656,6 +1640,7 @@ func (literal *MapLiteral) Accept(visitor Visitor) error {
 }
 
 type PropertyKeyName struct {
+	NodeSpan
 	Name SchemaName
 	Expr Expr
 }
@@ -673,7 +1658,97 @@ func (name *PropertyKeyName) Accept(visitor Visitor) error {
 	return visitor.VisitPropertyKeyNameLeave(name)
 }
 
+// MapProjectionExpr is a map projection such as n {.name, .*, key: expr, var}.
+type MapProjectionExpr struct {
+	NodeSpan
+	Variable  SymbolicName
+	Selectors []MapProjectionSelector
+}
+
+func (expr *MapProjectionExpr) Accept(visitor Visitor) error {
+	if err := visitor.VisitMapProjectionExprEnter(expr); err != nil {
+		return err
+	}
+	if err := expr.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	for _, selector := range expr.Selectors {
+		if err := selector.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitMapProjectionExprLeave(expr)
+}
+
+type MapProjectionSelector interface {
+	Node
+	Acceptor
+	mapProjectionSelectorNode()
+}
+
+// PropertySelector is .name in a map projection.
+type PropertySelector struct {
+	NodeSpan
+	Property SchemaName
+}
+
+func (selector *PropertySelector) Accept(visitor Visitor) error {
+	if err := visitor.VisitPropertySelectorEnter(selector); err != nil {
+		return err
+	}
+	if err := selector.Property.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitPropertySelectorLeave(selector)
+}
+
+// AllPropertiesSelector is .* in a map projection.
+type AllPropertiesSelector struct {
+	NodeSpan
+}
+
+func (selector *AllPropertiesSelector) Accept(visitor Visitor) error {
+	return visitor.VisitAllPropertiesSelector(selector)
+}
+
+// LiteralEntrySelector is key: expr in a map projection.
+type LiteralEntrySelector struct {
+	NodeSpan
+	Key  SchemaName
+	Expr Expr
+}
+
+func (selector *LiteralEntrySelector) Accept(visitor Visitor) error {
+	if err := visitor.VisitLiteralEntrySelectorEnter(selector); err != nil {
+		return err
+	}
+	if err := selector.Key.Accept(visitor); err != nil {
+		return err
+	}
+	if err := selector.Expr.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitLiteralEntrySelectorLeave(selector)
+}
+
+// VariableSelector is var in a map projection, which adds the entry var: var.
+type VariableSelector struct {
+	NodeSpan
+	Variable SymbolicName
+}
+
+func (selector *VariableSelector) Accept(visitor Visitor) error {
+	if err := visitor.VisitVariableSelectorEnter(selector); err != nil {
+		return err
+	}
+	if err := selector.Variable.Accept(visitor); err != nil {
+		return err
+	}
+	return visitor.VisitVariableSelectorLeave(selector)
+}
+
 type Properties struct {
+	NodeSpan
 	MapLiteral *MapLiteral
 	Parameter  Expr
 }
@@ -696,6 +1771,7 @@ func (props *Properties) Accept(visitor Visitor) error {
 }
 
 type RelationshipsPattern struct {
+	NodeSpan
 	Left  *NodePattern
 	Chain []*PatternElementChain
 }
@@ -716,6 +1792,7 @@ func (pattern *RelationshipsPattern) Accept(visitor Visitor) error {
 }
 
 type PatternElementChain struct {
+	NodeSpan
 	RelationshipPattern *RelationshipPattern
 	Right               *NodePattern
 }
@@ -734,6 +1811,7 @@ func (chain *PatternElementChain) Accept(visitor Visitor) error {
 }
 
 type RelationshipPattern struct {
+	NodeSpan
 	Left               Relationship
 	Right              Relationship
 	RelationshipDetail *RelationshipDetail
@@ -751,11 +1829,16 @@ func (pattern *RelationshipPattern) Accept(visitor Visitor) error {
 	return visitor.VisitRelationshipPatternLeave(pattern)
 }
 
+// RelationshipDetail holds a disjunction of types, [r:A|B], in RelationshipTypes and any other label
+// expression in LabelExpr. WhereExpr is the inline predicate of [r WHERE r.since < 2000].
 type RelationshipDetail struct {
+	NodeSpan
 	Variable          SymbolicName
 	RelationshipTypes []SchemaName
+	LabelExpr         LabelExpr
 	RangeLiteral      *RangeLiteral
 	Properties        *Properties
+	WhereExpr         Expr
 }
 
 func (detail *RelationshipDetail) Accept(visitor Visitor) error {
@@ -772,6 +1855,11 @@ func (detail *RelationshipDetail) Accept(visitor Visitor) error {
 			return err
 		}
 	}
+	if detail.LabelExpr != nil {
+		if err := detail.LabelExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	if err := detail.RangeLiteral.Accept(visitor); err != nil {
 		return err
 	}
@@ -780,10 +1868,16 @@ func (detail *RelationshipDetail) Accept(visitor Visitor) error {
 			return err
 		}
 	}
+	if detail.WhereExpr != nil {
+		if err := detail.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
 	return visitor.VisitRelationshipDetailLeave(detail)
 }
 
 type RangeLiteral struct {
+	NodeSpan
 	Begin int64
 	End   int64
 }
@@ -793,6 +1887,7 @@ func (literal *RangeLiteral) Accept(visitor Visitor) error {
 }
 
 type FunctionInvocation struct {
+	NodeSpan
 	FunctionName FunctionName
 	Distinct     bool
 	Args         []Expr
@@ -814,11 +1909,13 @@ func (fnc *FunctionInvocation) Accept(visitor Visitor) error {
 }
 
 type FunctionName interface {
+	Node
 	Acceptor
 	functionNameNode()
 }
 
 type SymbolicFunctionName struct {
+	NodeSpan
 	Namespace    []SymbolicName
 	FunctionName SymbolicName
 }
@@ -839,6 +1936,7 @@ func (name *SymbolicFunctionName) Accept(visitor Visitor) error {
 }
 
 type ListOperatorExpr struct {
+	NodeSpan
 	Op      Operator
 	Expr    Expr
 	EndExpr Expr
@@ -848,27 +1946,128 @@ func (expr *ListOperatorExpr) Accept(visitor Visitor) error {
 	if err := visitor.VisitListOperatorExprEnter(expr); err != nil {
 		return err
 	}
-	if err := expr.Expr.Accept(visitor); err != nil {
+	if expr.Expr != nil {
+		if err := expr.Expr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if expr.EndExpr != nil {
+		if err := expr.EndExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitListOperatorExprLeave(expr)
+}
+
+// ExistsSubqueryExpr is EXISTS { ... }. The subquery is either a pattern with an optional WHERE expression,
+// or a full query, in which case Pattern is nil.
+type ExistsSubqueryExpr struct {
+	NodeSpan
+	Pattern   *Pattern
+	WhereExpr Expr
+	Query     Query
+}
+
+func (expr *ExistsSubqueryExpr) Accept(visitor Visitor) error {
+	if err := visitor.VisitExistsSubqueryExprEnter(expr); err != nil {
 		return err
 	}
-	if err := expr.EndExpr.Accept(visitor); err != nil {
+	if expr.Pattern != nil {
+		if err := expr.Pattern.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if expr.WhereExpr != nil {
+		if err := expr.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if expr.Query != nil {
+		if err := expr.Query.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitExistsSubqueryExprLeave(expr)
+}
+
+// CountSubqueryExpr is COUNT { ... }, with the same forms of subquery as ExistsSubqueryExpr.
+type CountSubqueryExpr struct {
+	NodeSpan
+	Pattern   *Pattern
+	WhereExpr Expr
+	Query     Query
+}
+
+func (expr *CountSubqueryExpr) Accept(visitor Visitor) error {
+	if err := visitor.VisitCountSubqueryExprEnter(expr); err != nil {
 		return err
 	}
-	return visitor.VisitListOperatorExprLeave(expr)
+	if expr.Pattern != nil {
+		if err := expr.Pattern.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if expr.WhereExpr != nil {
+		if err := expr.WhereExpr.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	if expr.Query != nil {
+		if err := expr.Query.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return visitor.VisitCountSubqueryExprLeave(expr)
 }
 
-type ExistsFunctionName struct{}
+type ExistsFunctionName struct {
+	NodeSpan
+}
 
 func (name *ExistsFunctionName) Accept(visitor Visitor) error {
 	return visitor.VisitExistsFunctionName(name)
 }
 
-func (q *SinglePartQuery) queryNode()       {}
-func (q *CreateClause) updatingClauseNode() {}
-func (q *MatchClause) readingClauseNode()   {}
-
-func (p *PatternElementPattern) patternElementNode() {}
-func (p *PatternElementNested) patternElementNode()  {}
+func (q *UnionQuery) statementNode()           {}
+func (q *StandaloneCall) statementNode()       {}
+func (q *SinglePartQuery) statementNode()      {}
+func (q *MultiPartQuery) statementNode()       {}
+func (c *CreateIndex) statementNode()          {}
+func (c *DropIndex) statementNode()            {}
+func (c *CreateConstraint) statementNode()     {}
+func (c *DropConstraint) statementNode()       {}
+func (q *UnionQuery) queryNode()               {}
+func (q *StandaloneCall) queryNode()           {}
+func (q *SinglePartQuery) queryNode()          {}
+func (q *MultiPartQuery) queryNode()           {}
+func (c *CreateIndex) schemaCommandNode()      {}
+func (c *DropIndex) schemaCommandNode()        {}
+func (c *CreateConstraint) schemaCommandNode() {}
+func (c *DropConstraint) schemaCommandNode()   {}
+func (q *CreateClause) updatingClauseNode()    {}
+func (q *MergeClause) updatingClauseNode()     {}
+func (q *SetClause) updatingClauseNode()       {}
+func (q *RemoveClause) updatingClauseNode()    {}
+func (q *DeleteClause) updatingClauseNode()    {}
+func (q *ForeachClause) updatingClauseNode()   {}
+func (q *MatchClause) readingClauseNode()      {}
+func (q *UnwindClause) readingClauseNode()     {}
+func (q *InQueryCall) readingClauseNode()      {}
+func (q *LoadCSVClause) readingClauseNode()    {}
+func (q *SubqueryCall) readingClauseNode()     {}
+
+func (i *PropertySetItem) setItemNode()    {}
+func (i *VariableSetItem) setItemNode()    {}
+func (i *VariableAddSetItem) setItemNode() {}
+func (i *LabelsSetItem) setItemNode()      {}
+
+func (i *LabelsRemoveItem) removeItemNode()   {}
+func (i *PropertyRemoveItem) removeItemNode() {}
+
+func (p *PatternElementPattern) patternElementNode()  {}
+func (p *PatternElementNested) patternElementNode()   {}
+func (p *PatternElementSequence) patternElementNode() {}
+func (p *ShortestPathPattern) patternElementNode()    {}
 
 func (e *OpExpr) exprNode()                   {}
 func (e *UnaryExpr) exprNode()                {}
@@ -889,6 +2088,26 @@ func (e *PatternComprehensionExpr) exprNode() {}
 func (e *RelationshipsPattern) exprNode()     {}
 func (e *FunctionInvocation) exprNode()       {}
 func (e *ListOperatorExpr) exprNode()         {}
+func (e *ShortestPathPattern) exprNode()      {}
+func (e *ExistsSubqueryExpr) exprNode()       {}
+func (e *CountSubqueryExpr) exprNode()        {}
+func (e *MapProjectionExpr) exprNode()        {}
+func (e *TypePredicateExpr) exprNode()        {}
+
+func (t *SimpleType) cypherTypeNode() {}
+func (t *ListType) cypherTypeNode()   {}
+func (t *UnionType) cypherTypeNode()  {}
+
+func (s *PropertySelector) mapProjectionSelectorNode()      {}
+func (s *AllPropertiesSelector) mapProjectionSelectorNode() {}
+func (s *LiteralEntrySelector) mapProjectionSelectorNode()  {}
+func (s *VariableSelector) mapProjectionSelectorNode()      {}
+
+func (e *LabelName) labelExprNode()     {}
+func (e *LabelWildcard) labelExprNode() {}
+func (e *LabelNot) labelExprNode()      {}
+func (e *LabelAnd) labelExprNode()      {}
+func (e *LabelOr) labelExprNode()       {}
 
 func (s *SymbolicNameIdentifier) symbolicNameNode() {}
 func (s *SymbolicNameHexLetter) symbolicNameNode()  {}
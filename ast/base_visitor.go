@@ -0,0 +1,747 @@
+package ast
+
+// BaseVisitor implements every method of Visitor by doing nothing and returning nil. Embed it in a visitor
+// to implement only the methods it needs.
+type BaseVisitor struct{}
+
+var _ Visitor = BaseVisitor{}
+
+func (BaseVisitor) VisitUnionQueryEnter(query *UnionQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnionQueryLeave(query *UnionQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnionEnter(union *Union) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnionLeave(union *Union) error {
+	return nil
+}
+
+func (BaseVisitor) VisitStandaloneCallEnter(call *StandaloneCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitStandaloneCallLeave(call *StandaloneCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSinglePartQueryEnter(query *SinglePartQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSinglePartQueryLeave(query *SinglePartQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMultiPartQueryEnter(query *MultiPartQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMultiPartQueryLeave(query *MultiPartQuery) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMultiPartQueryPartEnter(part *MultiPartQueryPart) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMultiPartQueryPartLeave(part *MultiPartQueryPart) error {
+	return nil
+}
+
+func (BaseVisitor) VisitReadingClauseEnter(clause []ReadingClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitReadingClauseLeave(clause []ReadingClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUpdatingClauseEnter(clause []UpdatingClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUpdatingClauseLeave(clause []UpdatingClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateEnter(clause *CreateClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateLeave(clause *CreateClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitInQueryCallEnter(call *InQueryCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitInQueryCallLeave(call *InQueryCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSubqueryCallEnter(call *SubqueryCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSubqueryCallLeave(call *SubqueryCall) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProcedureInvocationEnter(inv *ProcedureInvocation) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProcedureInvocationLeave(inv *ProcedureInvocation) error {
+	return nil
+}
+
+func (BaseVisitor) VisitYieldItemsEnter(items *YieldItems) error {
+	return nil
+}
+
+func (BaseVisitor) VisitYieldItemsLeave(items *YieldItems) error {
+	return nil
+}
+
+func (BaseVisitor) VisitYieldItemEnter(item *YieldItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitYieldItemLeave(item *YieldItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMergeEnter(clause *MergeClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMergeLeave(clause *MergeClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMergeActionEnter(action *MergeAction) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMergeActionLeave(action *MergeAction) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSetEnter(clause *SetClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSetLeave(clause *SetClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertySetItemEnter(item *PropertySetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertySetItemLeave(item *PropertySetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableSetItemEnter(item *VariableSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableSetItemLeave(item *VariableSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableAddSetItemEnter(item *VariableAddSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableAddSetItemLeave(item *VariableAddSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelsSetItemEnter(item *LabelsSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelsSetItemLeave(item *LabelsSetItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRemoveEnter(clause *RemoveClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRemoveLeave(clause *RemoveClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelsRemoveItemEnter(item *LabelsRemoveItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelsRemoveItemLeave(item *LabelsRemoveItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyRemoveItemEnter(item *PropertyRemoveItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyRemoveItemLeave(item *PropertyRemoveItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDeleteEnter(clause *DeleteClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDeleteLeave(clause *DeleteClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitForeachEnter(clause *ForeachClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitForeachLeave(clause *ForeachClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateIndexEnter(command *CreateIndex) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateIndexLeave(command *CreateIndex) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDropIndexEnter(command *DropIndex) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDropIndexLeave(command *DropIndex) error {
+	return nil
+}
+
+func (BaseVisitor) VisitIndexDefinitionEnter(def *IndexDefinition) error {
+	return nil
+}
+
+func (BaseVisitor) VisitIndexDefinitionLeave(def *IndexDefinition) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateConstraintEnter(command *CreateConstraint) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCreateConstraintLeave(command *CreateConstraint) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDropConstraintEnter(command *DropConstraint) error {
+	return nil
+}
+
+func (BaseVisitor) VisitDropConstraintLeave(command *DropConstraint) error {
+	return nil
+}
+
+func (BaseVisitor) VisitConstraintDefinitionEnter(def *ConstraintDefinition) error {
+	return nil
+}
+
+func (BaseVisitor) VisitConstraintDefinitionLeave(def *ConstraintDefinition) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMatchEnter(clause *MatchClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMatchLeave(clause *MatchClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnwindEnter(clause *UnwindClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnwindLeave(clause *UnwindClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLoadCSVEnter(clause *LoadCSVClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLoadCSVLeave(clause *LoadCSVClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitWithEnter(clause *WithClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitWithLeave(clause *WithClause) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternEnter(pattern *Pattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternLeave(pattern *Pattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternPartEnter(part *PatternPart) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternPartLeave(part *PatternPart) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementNestedEnter(part *PatternElementNested) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementNestedLeave(part *PatternElementNested) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementSequenceEnter(part *PatternElementSequence) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementSequenceLeave(part *PatternElementSequence) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPathSelector(selector *PathSelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementPatternEnter(part *PatternElementPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementPatternLeave(part *PatternElementPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitShortestPathPatternEnter(part *ShortestPathPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitShortestPathPatternLeave(part *ShortestPathPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionEnter(projection *Projection) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionLeave(projection *Projection) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSortOrderEnter(order *SortOrder) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSortOrderLeave(order *SortOrder) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionItemsEnter(items *ProjectionItems) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionItemsLeave(items *ProjectionItems) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionItemEnter(item *ProjectionItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitProjectionItemLeave(item *ProjectionItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSortItemEnter(item *SortItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSortItemLeave(item *SortItem) error {
+	return nil
+}
+
+func (BaseVisitor) VisitOpExpr(expr *OpExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnaryExprEnter(expr *UnaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnaryExprLeave(expr *UnaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitBinaryExprEnter(expr *BinaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitBinaryExprLeave(expr *BinaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitTernaryExprEnter(expr *TernaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitTernaryExprLeave(expr *TernaryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListExprEnter(expr *ListExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListExprLeave(expr *ListExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListComprehensionExprEnter(expr *ListComprehensionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListComprehensionExprLeave(expr *ListComprehensionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyLabelsExprEnter(expr *PropertyLabelsExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyLabelsExprLeave(expr *PropertyLabelsExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicNameSchemaNameEnter(name *SymbolicNameSchemaName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicNameSchemaNameLeave(name *SymbolicNameSchemaName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitReservedWordSchemaName(name *ReservedWordSchemaName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicNameIdentifier(name *SymbolicNameIdentifier) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicNameHexLetter(name *SymbolicNameHexLetter) error {
+	return nil
+}
+
+func (BaseVisitor) VisitReservedWord(word *ReservedWord) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabel(label *Label) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPrimitiveLiteral(literal *PrimitiveLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListLiteralEnter(literal *ListLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListLiteralLeave(literal *ListLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitParameterEnter(param *Parameter) error {
+	return nil
+}
+
+func (BaseVisitor) VisitParameterLeave(param *Parameter) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCaseExprEnter(expr *CaseExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCaseExprLeave(expr *CaseExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCaseAltNodeEnter(alt *CaseAltNode) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCaseAltNodeLeave(alt *CaseAltNode) error {
+	return nil
+}
+
+func (BaseVisitor) VisitQuantifierExprEnter(quantifier *QuantifierExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitQuantifierExprLeave(quantifier *QuantifierExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitFilterExprEnter(filter *FilterExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitFilterExprLeave(filter *FilterExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableExprEnter(expr *VariableExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableExprLeave(expr *VariableExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternComprehensionExprEnter(expr *PatternComprehensionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternComprehensionExprLeave(expr *PatternComprehensionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitNodePatternEnter(pattern *NodePattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitNodePatternLeave(pattern *NodePattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMapLiteralEnter(literal *MapLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMapLiteralLeave(literal *MapLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyKeyNameEnter(name *PropertyKeyName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertyKeyNameLeave(name *PropertyKeyName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMapProjectionExprEnter(expr *MapProjectionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitMapProjectionExprLeave(expr *MapProjectionExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertySelectorEnter(selector *PropertySelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertySelectorLeave(selector *PropertySelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitAllPropertiesSelector(selector *AllPropertiesSelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLiteralEntrySelectorEnter(selector *LiteralEntrySelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLiteralEntrySelectorLeave(selector *LiteralEntrySelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableSelectorEnter(selector *VariableSelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitVariableSelectorLeave(selector *VariableSelector) error {
+	return nil
+}
+
+func (BaseVisitor) VisitTypePredicateExprEnter(expr *TypePredicateExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitTypePredicateExprLeave(expr *TypePredicateExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSimpleType(t *SimpleType) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListTypeEnter(t *ListType) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListTypeLeave(t *ListType) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnionTypeEnter(t *UnionType) error {
+	return nil
+}
+
+func (BaseVisitor) VisitUnionTypeLeave(t *UnionType) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelNameEnter(expr *LabelName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelNameLeave(expr *LabelName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelWildcard(expr *LabelWildcard) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelNotEnter(expr *LabelNot) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelNotLeave(expr *LabelNot) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelAndEnter(expr *LabelAnd) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelAndLeave(expr *LabelAnd) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelOrEnter(expr *LabelOr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitLabelOrLeave(expr *LabelOr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertiesEnter(props *Properties) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPropertiesLeave(props *Properties) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipsPatternEnter(pattern *RelationshipsPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipsPatternLeave(pattern *RelationshipsPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementChainEnter(chain *PatternElementChain) error {
+	return nil
+}
+
+func (BaseVisitor) VisitPatternElementChainLeave(chain *PatternElementChain) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipPatternEnter(pattern *RelationshipPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipPatternLeave(pattern *RelationshipPattern) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipDetailEnter(detail *RelationshipDetail) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRelationshipDetailLeave(detail *RelationshipDetail) error {
+	return nil
+}
+
+func (BaseVisitor) VisitRangeLiteral(literal *RangeLiteral) error {
+	return nil
+}
+
+func (BaseVisitor) VisitFunctionInvocationEnter(fnc *FunctionInvocation) error {
+	return nil
+}
+
+func (BaseVisitor) VisitFunctionInvocationLeave(fnc *FunctionInvocation) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicFunctionNameEnter(name *SymbolicFunctionName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitSymbolicFunctionNameLeave(name *SymbolicFunctionName) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListOperatorExprEnter(expr *ListOperatorExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitListOperatorExprLeave(expr *ListOperatorExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitExistsSubqueryExprEnter(expr *ExistsSubqueryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitExistsSubqueryExprLeave(expr *ExistsSubqueryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCountSubqueryExprEnter(expr *CountSubqueryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitCountSubqueryExprLeave(expr *CountSubqueryExpr) error {
+	return nil
+}
+
+func (BaseVisitor) VisitExistsFunctionName(name *ExistsFunctionName) error {
+	return nil
+}
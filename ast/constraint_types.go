@@ -0,0 +1,8 @@
+package ast
+
+type ConstraintType int
+
+const (
+	UniqueConstraint ConstraintType = iota
+	ExistenceConstraint
+)
@@ -0,0 +1,8 @@
+package ast
+
+type MergeActionType int
+
+const (
+	OnMatch MergeActionType = iota
+	OnCreate
+)
@@ -0,0 +1,9 @@
+package ast
+
+type PathSelectorKind int
+
+const (
+	AnyShortestPath PathSelectorKind = iota
+	AllShortestPaths
+	ShortestKPaths
+)
@@ -0,0 +1,227 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Inspect traverses the AST rooted at node in depth-first order. It calls f(node), and if f returns true,
+// traverses each of the non-nil children of node, followed by a call of f(nil). Children are traversed in
+// the order of the fields of their parent.
+func Inspect(node Node, f func(Node) bool) {
+	Rewrite(node, func(c *Cursor) bool {
+		return f(c.Node())
+	}, func(c *Cursor) bool {
+		f(nil)
+		return true
+	})
+}
+
+// A RewriteFunc is called by Rewrite for each node it traverses. Returning false stops the traversal of the
+// node's children, when returned before them, or of the rest of the tree, when returned after them.
+type RewriteFunc func(*Cursor) bool
+
+// Rewrite traverses the AST rooted at root, calling pre before traversing the non-nil children of each node
+// and post after. Either function may be nil. Children are traversed in the order of the fields of their
+// parent, and the Cursor passed to pre and post describes the node and can replace, delete or insert
+// nodes around it.
+//
+// If pre returns false the node's children are not traversed and post is not called for it. If post
+// returns false the traversal stops. Nodes given to Replace, InsertBefore and InsertAfter are not
+// traversed, and the children traversed after Replace are those of the node replaced.
+//
+// Rewrite returns root, or its replacement.
+func Rewrite(root Node, pre, post RewriteFunc) (result Node) {
+	parent := &rewriteRoot{Node: root}
+	defer func() {
+		if r := recover(); r != nil && r != abort {
+			panic(r)
+		}
+		result = parent.Node
+	}()
+	a := &application{pre: pre, post: post}
+	a.apply(parent, "Node", nil, root)
+	return parent.Node
+}
+
+// rewriteRoot holds the root of the AST being rewritten, so it can be replaced like any other node.
+type rewriteRoot struct {
+	NodeSpan
+	Node Node
+}
+
+var abort = new(int)
+
+// A Cursor describes a node traversed by Rewrite, and where it is held by its parent.
+type Cursor struct {
+	parent Node
+	name   string
+	iter   *iterator
+	node   Node
+}
+
+// iterator is the position of a node in a list, and the step to the next node once it is traversed.
+type iterator struct {
+	index, step int
+}
+
+// Node returns the current node.
+func (c *Cursor) Node() Node {
+	return c.node
+}
+
+// Parent returns the parent of the current node, which is nil for the root.
+func (c *Cursor) Parent() Node {
+	if _, ok := c.parent.(*rewriteRoot); ok {
+		return nil
+	}
+	return c.parent
+}
+
+// Name returns the name of the field of the parent holding the current node, such as "Left" for the left
+// operand of a BinaryExpr.
+func (c *Cursor) Name() string {
+	return c.name
+}
+
+// Index returns the index of the current node in the list held by the field of its parent, or -1 if the
+// field is not a list.
+func (c *Cursor) Index() int {
+	if c.iter == nil {
+		return -1
+	}
+	return c.iter.index
+}
+
+func (c *Cursor) field() reflect.Value {
+	return reflect.ValueOf(c.parent).Elem().FieldByName(c.name)
+}
+
+// Replace replaces the current node with node, which may be nil. It panics if node cannot be held by the
+// field of the parent.
+func (c *Cursor) Replace(node Node) {
+	v := c.field()
+	if i := c.Index(); i >= 0 {
+		v = v.Index(i)
+	}
+	v.Set(nodeValue(v.Type(), node))
+	c.node = node
+}
+
+// Delete deletes the current node from the list holding it. It panics if the node is not in a list.
+func (c *Cursor) Delete() {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: Delete of %s node not in a list", c.name))
+	}
+	v := c.field()
+	l := v.Len()
+	reflect.Copy(v.Slice(i, l), v.Slice(i+1, l))
+	v.Index(l - 1).Set(reflect.Zero(v.Type().Elem()))
+	v.SetLen(l - 1)
+	c.iter.step--
+}
+
+// InsertAfter inserts node after the current node in the list holding it. It panics if the current node is
+// not in a list.
+func (c *Cursor) InsertAfter(node Node) {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: InsertAfter of %s node not in a list", c.name))
+	}
+	c.insert(i+1, node)
+	c.iter.step++
+}
+
+// InsertBefore inserts node before the current node in the list holding it. It panics if the current node
+// is not in a list.
+func (c *Cursor) InsertBefore(node Node) {
+	i := c.Index()
+	if i < 0 {
+		panic(fmt.Sprintf("ast: InsertBefore of %s node not in a list", c.name))
+	}
+	c.insert(i, node)
+	c.iter.index++
+}
+
+func (c *Cursor) insert(i int, node Node) {
+	v := c.field()
+	e := nodeValue(v.Type().Elem(), node)
+	v.Set(reflect.Append(v, e))
+	l := v.Len()
+	reflect.Copy(v.Slice(i+1, l), v.Slice(i, l-1))
+	v.Index(i).Set(e)
+}
+
+// nodeValue returns node as a value of type t, a node interface or pointer type.
+func nodeValue(t reflect.Type, node Node) reflect.Value {
+	if node == nil {
+		return reflect.Zero(t)
+	}
+	return reflect.ValueOf(node)
+}
+
+type application struct {
+	pre, post RewriteFunc
+	cursor    Cursor
+	iter      iterator
+}
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+
+func (a *application) apply(parent Node, name string, iter *iterator, node Node) {
+	if v := reflect.ValueOf(node); !v.IsValid() || v.Kind() == reflect.Pointer && v.IsNil() {
+		return
+	}
+	saved := a.cursor
+	a.cursor = Cursor{parent: parent, name: name, iter: iter, node: node}
+	if a.pre != nil && !a.pre(&a.cursor) {
+		a.cursor = saved
+		return
+	}
+	a.applyChildren(node)
+	if a.post != nil && !a.post(&a.cursor) {
+		panic(abort)
+	}
+	a.cursor = saved
+}
+
+// applyChildren applies the traversal to the children of node: the fields holding a node, or a list of
+// them.
+func (a *application) applyChildren(node Node) {
+	v := reflect.ValueOf(node).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		switch {
+		case field.Type.Implements(nodeType):
+			if child := v.Field(i); !child.IsNil() {
+				a.apply(node, field.Name, nil, child.Interface().(Node))
+			}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Implements(nodeType):
+			a.applyList(node, field.Name)
+		}
+	}
+}
+
+func (a *application) applyList(parent Node, name string) {
+	saved := a.iter
+	a.iter.index = 0
+	for {
+		v := reflect.ValueOf(parent).Elem().FieldByName(name)
+		if a.iter.index >= v.Len() {
+			break
+		}
+		var node Node
+		if e := v.Index(a.iter.index); !e.IsNil() {
+			node = e.Interface().(Node)
+		}
+		a.iter.step = 1
+		a.apply(parent, name, &a.iter, node)
+		a.iter.index += a.iter.step
+	}
+	a.iter = saved
+}
@@ -0,0 +1,212 @@
+package ast_test
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/format"
+	"github.com/mburbidg/cypher/parser"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/mburbidg/cypher/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type testReporter struct{}
+
+func (r testReporter) Error(line int, msg string) error {
+	return utils.ParseError{Line: line, Msg: msg}
+}
+
+func parse(t *testing.T, src string) ast.Statement {
+	reporter := testReporter{}
+	stmt, err := parser.New(scanner.New([]byte(src), reporter), reporter).Parse()
+	require.NoError(t, err)
+	return stmt.AST
+}
+
+func text(t *testing.T, node ast.Node) string {
+	s, err := format.Format(node, format.Options{KeywordCase: format.UpperCase})
+	require.NoError(t, err)
+	return s
+}
+
+func variable(name string) *ast.VariableExpr {
+	return &ast.VariableExpr{SymbolicName: &ast.SymbolicNameIdentifier{Identifier: scanner.Token{T: scanner.Identifier, Lexeme: name}}}
+}
+
+func name(node ast.Node) string {
+	if n, ok := node.(*ast.SymbolicNameIdentifier); ok {
+		return n.Identifier.Lexeme
+	}
+	return ""
+}
+
+func TestInspect(t *testing.T) {
+	stmt := parse(t, "MATCH (a)-[r]->(b) WHERE a.x > [y IN b.l WHERE y > 0 | y] RETURN a, r")
+	names := []string{}
+	depth, maxDepth := 0, 0
+	ast.Inspect(stmt, func(node ast.Node) bool {
+		if node == nil {
+			depth--
+			return true
+		}
+		depth++
+		if depth > maxDepth {
+			maxDepth = depth
+		}
+		if n := name(node); n != "" {
+			names = append(names, n)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "r", "b", "a", "x", "y", "b", "l", "y", "y", "a", "r"}, names)
+	assert.Equal(t, 0, depth)
+	assert.Greater(t, maxDepth, 5)
+
+	// Children are not traversed when f returns false.
+	names = []string{}
+	ast.Inspect(stmt, func(node ast.Node) bool {
+		if _, ok := node.(*ast.ListComprehensionExpr); ok {
+			return false
+		}
+		if n := name(node); n != "" {
+			names = append(names, n)
+		}
+		return true
+	})
+	assert.Equal(t, []string{"a", "r", "b", "a", "x", "a", "r"}, names)
+}
+
+func TestRewrite(t *testing.T) {
+	tests := map[string]struct {
+		src       string
+		pre       ast.RewriteFunc
+		post      ast.RewriteFunc
+		rewritten string
+	}{
+		"replace": {
+			"RETURN a + b, a",
+			func(c *ast.Cursor) bool {
+				if expr, ok := c.Node().(*ast.VariableExpr); ok && name(expr.SymbolicName) == "a" {
+					c.Replace(variable("c"))
+				}
+				return true
+			},
+			nil,
+			"RETURN c + b, c",
+		},
+		"replace after children": {
+			"RETURN 1 + 2 * 3",
+			nil,
+			func(c *ast.Cursor) bool {
+				if expr, ok := c.Node().(*ast.BinaryExpr); ok && expr.Op == ast.Multiply {
+					c.Replace(expr.Right)
+				}
+				return true
+			},
+			"RETURN 1 + 3",
+		},
+		"delete": {
+			"MATCH (a), (b), (c) RETURN a",
+			func(c *ast.Cursor) bool {
+				if part, ok := c.Node().(*ast.PatternPart); ok && c.Name() == "Parts" && c.Index() < 2 {
+					element := part.Element.(*ast.PatternElementPattern)
+					if name(element.Left.Variable) != "c" {
+						c.Delete()
+					}
+				}
+				return true
+			},
+			nil,
+			"MATCH (c)\nRETURN a",
+		},
+		"insert": {
+			"RETURN a, b",
+			func(c *ast.Cursor) bool {
+				if item, ok := c.Node().(*ast.ProjectionItem); ok {
+					n := name(item.Expr.(*ast.PropertyLabelsExpr).Atom.(*ast.VariableExpr).SymbolicName)
+					c.InsertBefore(&ast.ProjectionItem{Expr: variable("before_" + n)})
+					c.InsertAfter(&ast.ProjectionItem{Expr: variable("after_" + n)})
+				}
+				return true
+			},
+			nil,
+			"RETURN before_a, a, after_a, before_b, b, after_b",
+		},
+		"replace root": {
+			"RETURN a",
+			func(c *ast.Cursor) bool {
+				if c.Parent() == nil {
+					c.Replace(parse(t, "RETURN b"))
+					return false
+				}
+				return true
+			},
+			nil,
+			"RETURN b",
+		},
+		"stop": {
+			"RETURN a, b, c",
+			nil,
+			func(c *ast.Cursor) bool {
+				if expr, ok := c.Node().(*ast.VariableExpr); ok {
+					c.Replace(variable("x"))
+					return name(expr.SymbolicName) != "b"
+				}
+				return true
+			},
+			"RETURN x, x, c",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := ast.Rewrite(parse(t, tc.src), tc.pre, tc.post)
+			assert.Equal(t, tc.rewritten, text(t, result))
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	stmt := parse(t, "RETURN a, b")
+	items := stmt.(*ast.SinglePartQuery).Projection.Items
+	i := 0
+	ast.Rewrite(stmt, func(c *ast.Cursor) bool {
+		if _, ok := c.Node().(*ast.ProjectionItem); ok {
+			assert.Equal(t, items, c.Parent())
+			assert.Equal(t, "Items", c.Name())
+			assert.Equal(t, i, c.Index())
+			assert.Equal(t, items.Items[i], c.Node())
+			i++
+		}
+		if _, ok := c.Node().(*ast.ProjectionItems); ok {
+			assert.Equal(t, -1, c.Index())
+		}
+		return true
+	}, nil)
+	assert.Equal(t, 2, i)
+
+	assert.Panics(t, func() {
+		ast.Rewrite(stmt, func(c *ast.Cursor) bool {
+			if _, ok := c.Node().(*ast.ProjectionItems); ok {
+				c.Delete()
+			}
+			return true
+		}, nil)
+	})
+}
+
+type variableCounter struct {
+	ast.BaseVisitor
+	count int
+}
+
+func (v *variableCounter) VisitVariableExprEnter(expr *ast.VariableExpr) error {
+	v.count++
+	return nil
+}
+
+func TestBaseVisitor(t *testing.T) {
+	counter := &variableCounter{}
+	require.NoError(t, parse(t, "MATCH (n) WHERE n.x = m RETURN n, [x IN l | x]").Accept(counter))
+	assert.Equal(t, 5, counter.count)
+}
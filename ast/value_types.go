@@ -0,0 +1,25 @@
+package ast
+
+type ValueType int
+
+const (
+	AnyValue ValueType = iota
+	NothingValue
+	NullValue
+	BooleanValue
+	StringValue
+	IntegerValue
+	FloatValue
+	DateValue
+	LocalTimeValue
+	ZonedTimeValue
+	LocalDateTimeValue
+	ZonedDateTimeValue
+	DurationValue
+	PointValue
+	NodeValue
+	RelationshipValue
+	PathValue
+	MapValue
+	PropertyValue
+)
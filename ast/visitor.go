@@ -1,24 +1,91 @@
 package ast
 
 type Visitor interface {
+	VisitUnionQueryEnter(query *UnionQuery) error
+	VisitUnionQueryLeave(query *UnionQuery) error
+	VisitUnionEnter(union *Union) error
+	VisitUnionLeave(union *Union) error
+	VisitStandaloneCallEnter(call *StandaloneCall) error
+	VisitStandaloneCallLeave(call *StandaloneCall) error
 	VisitSinglePartQueryEnter(query *SinglePartQuery) error
 	VisitSinglePartQueryLeave(query *SinglePartQuery) error
+	VisitMultiPartQueryEnter(query *MultiPartQuery) error
+	VisitMultiPartQueryLeave(query *MultiPartQuery) error
+	VisitMultiPartQueryPartEnter(part *MultiPartQueryPart) error
+	VisitMultiPartQueryPartLeave(part *MultiPartQueryPart) error
 	VisitReadingClauseEnter(clause []ReadingClause) error
 	VisitReadingClauseLeave(clause []ReadingClause) error
 	VisitUpdatingClauseEnter(clause []UpdatingClause) error
 	VisitUpdatingClauseLeave(clause []UpdatingClause) error
 	VisitCreateEnter(clause *CreateClause) error
 	VisitCreateLeave(clause *CreateClause) error
+	VisitInQueryCallEnter(call *InQueryCall) error
+	VisitInQueryCallLeave(call *InQueryCall) error
+	VisitSubqueryCallEnter(call *SubqueryCall) error
+	VisitSubqueryCallLeave(call *SubqueryCall) error
+	VisitProcedureInvocationEnter(inv *ProcedureInvocation) error
+	VisitProcedureInvocationLeave(inv *ProcedureInvocation) error
+	VisitYieldItemsEnter(items *YieldItems) error
+	VisitYieldItemsLeave(items *YieldItems) error
+	VisitYieldItemEnter(item *YieldItem) error
+	VisitYieldItemLeave(item *YieldItem) error
+	VisitMergeEnter(clause *MergeClause) error
+	VisitMergeLeave(clause *MergeClause) error
+	VisitMergeActionEnter(action *MergeAction) error
+	VisitMergeActionLeave(action *MergeAction) error
+	VisitSetEnter(clause *SetClause) error
+	VisitSetLeave(clause *SetClause) error
+	VisitPropertySetItemEnter(item *PropertySetItem) error
+	VisitPropertySetItemLeave(item *PropertySetItem) error
+	VisitVariableSetItemEnter(item *VariableSetItem) error
+	VisitVariableSetItemLeave(item *VariableSetItem) error
+	VisitVariableAddSetItemEnter(item *VariableAddSetItem) error
+	VisitVariableAddSetItemLeave(item *VariableAddSetItem) error
+	VisitLabelsSetItemEnter(item *LabelsSetItem) error
+	VisitLabelsSetItemLeave(item *LabelsSetItem) error
+	VisitRemoveEnter(clause *RemoveClause) error
+	VisitRemoveLeave(clause *RemoveClause) error
+	VisitLabelsRemoveItemEnter(item *LabelsRemoveItem) error
+	VisitLabelsRemoveItemLeave(item *LabelsRemoveItem) error
+	VisitPropertyRemoveItemEnter(item *PropertyRemoveItem) error
+	VisitPropertyRemoveItemLeave(item *PropertyRemoveItem) error
+	VisitDeleteEnter(clause *DeleteClause) error
+	VisitDeleteLeave(clause *DeleteClause) error
+	VisitForeachEnter(clause *ForeachClause) error
+	VisitForeachLeave(clause *ForeachClause) error
+	VisitCreateIndexEnter(command *CreateIndex) error
+	VisitCreateIndexLeave(command *CreateIndex) error
+	VisitDropIndexEnter(command *DropIndex) error
+	VisitDropIndexLeave(command *DropIndex) error
+	VisitIndexDefinitionEnter(def *IndexDefinition) error
+	VisitIndexDefinitionLeave(def *IndexDefinition) error
+	VisitCreateConstraintEnter(command *CreateConstraint) error
+	VisitCreateConstraintLeave(command *CreateConstraint) error
+	VisitDropConstraintEnter(command *DropConstraint) error
+	VisitDropConstraintLeave(command *DropConstraint) error
+	VisitConstraintDefinitionEnter(def *ConstraintDefinition) error
+	VisitConstraintDefinitionLeave(def *ConstraintDefinition) error
 	VisitMatchEnter(clause *MatchClause) error
 	VisitMatchLeave(clause *MatchClause) error
+	VisitUnwindEnter(clause *UnwindClause) error
+	VisitUnwindLeave(clause *UnwindClause) error
+	VisitLoadCSVEnter(clause *LoadCSVClause) error
+	VisitLoadCSVLeave(clause *LoadCSVClause) error
+	VisitWithEnter(clause *WithClause) error
+	VisitWithLeave(clause *WithClause) error
 	VisitPatternEnter(pattern *Pattern) error
 	VisitPatternLeave(pattern *Pattern) error
 	VisitPatternPartEnter(part *PatternPart) error
 	VisitPatternPartLeave(part *PatternPart) error
 	VisitPatternElementNestedEnter(part *PatternElementNested) error
 	VisitPatternElementNestedLeave(part *PatternElementNested) error
+	VisitPatternElementSequenceEnter(part *PatternElementSequence) error
+	VisitPatternElementSequenceLeave(part *PatternElementSequence) error
+	VisitPathSelector(selector *PathSelector) error
 	VisitPatternElementPatternEnter(part *PatternElementPattern) error
 	VisitPatternElementPatternLeave(part *PatternElementPattern) error
+	VisitShortestPathPatternEnter(part *ShortestPathPattern) error
+	VisitShortestPathPatternLeave(part *ShortestPathPattern) error
 	VisitProjectionEnter(projection *Projection) error
 	VisitProjectionLeave(projection *Projection) error
 	VisitSortOrderEnter(order *SortOrder) error
@@ -72,6 +139,31 @@ type Visitor interface {
 	VisitMapLiteralLeave(literal *MapLiteral) error
 	VisitPropertyKeyNameEnter(name *PropertyKeyName) error
 	VisitPropertyKeyNameLeave(name *PropertyKeyName) error
+	VisitMapProjectionExprEnter(expr *MapProjectionExpr) error
+	VisitMapProjectionExprLeave(expr *MapProjectionExpr) error
+	VisitPropertySelectorEnter(selector *PropertySelector) error
+	VisitPropertySelectorLeave(selector *PropertySelector) error
+	VisitAllPropertiesSelector(selector *AllPropertiesSelector) error
+	VisitLiteralEntrySelectorEnter(selector *LiteralEntrySelector) error
+	VisitLiteralEntrySelectorLeave(selector *LiteralEntrySelector) error
+	VisitVariableSelectorEnter(selector *VariableSelector) error
+	VisitVariableSelectorLeave(selector *VariableSelector) error
+	VisitTypePredicateExprEnter(expr *TypePredicateExpr) error
+	VisitTypePredicateExprLeave(expr *TypePredicateExpr) error
+	VisitSimpleType(t *SimpleType) error
+	VisitListTypeEnter(t *ListType) error
+	VisitListTypeLeave(t *ListType) error
+	VisitUnionTypeEnter(t *UnionType) error
+	VisitUnionTypeLeave(t *UnionType) error
+	VisitLabelNameEnter(expr *LabelName) error
+	VisitLabelNameLeave(expr *LabelName) error
+	VisitLabelWildcard(expr *LabelWildcard) error
+	VisitLabelNotEnter(expr *LabelNot) error
+	VisitLabelNotLeave(expr *LabelNot) error
+	VisitLabelAndEnter(expr *LabelAnd) error
+	VisitLabelAndLeave(expr *LabelAnd) error
+	VisitLabelOrEnter(expr *LabelOr) error
+	VisitLabelOrLeave(expr *LabelOr) error
 	VisitPropertiesEnter(props *Properties) error
 	VisitPropertiesLeave(props *Properties) error
 	VisitRelationshipsPatternEnter(pattern *RelationshipsPattern) error
@@ -89,5 +181,9 @@ type Visitor interface {
 	VisitSymbolicFunctionNameLeave(name *SymbolicFunctionName) error
 	VisitListOperatorExprEnter(expr *ListOperatorExpr) error
 	VisitListOperatorExprLeave(expr *ListOperatorExpr) error
+	VisitExistsSubqueryExprEnter(expr *ExistsSubqueryExpr) error
+	VisitExistsSubqueryExprLeave(expr *ExistsSubqueryExpr) error
+	VisitCountSubqueryExprEnter(expr *CountSubqueryExpr) error
+	VisitCountSubqueryExprLeave(expr *CountSubqueryExpr) error
 	VisitExistsFunctionName(name *ExistsFunctionName) error
 }
@@ -0,0 +1,551 @@
+// Package astjson encodes an AST as JSON, and decodes it back, for consumers of parse results written in
+// other languages.
+//
+// The document is an object holding the Version of the encoding and the encoded node:
+//
+//	{"version": 1, "ast": {"type": "SinglePartQuery", "span": {...}, "readingClause": [...], ...}}
+//
+// A node is an object whose "type" is the name of its Go type, "span" is the source range it was parsed
+// from, and whose other members are its fields, named as in Go but starting with a lower case letter. A
+// field named Type is named after its type instead, as in "valueType".
+// Absent nodes and lists are null. Operators, value types and the other enumerations are the names of their
+// constants, such as "Add". Tokens are objects with "tokenType", "lexeme", "literal", "line" and "span"
+// members; their trivia is not encoded.
+package astjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"reflect"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Version is incremented whenever the encoding changes in a way that consumers must be aware of, such as a
+// field being renamed or removed. Unmarshal rejects documents with a different version.
+const Version = 1
+
+// nodes are the types of every node, which are the types that can be encoded.
+var nodes = []ast.Node{
+	&ast.UnionQuery{},
+	&ast.Union{},
+	&ast.SinglePartQuery{},
+	&ast.StandaloneCall{},
+	&ast.MultiPartQuery{},
+	&ast.MultiPartQueryPart{},
+	&ast.WithClause{},
+	&ast.CreateClause{},
+	&ast.InQueryCall{},
+	&ast.SubqueryCall{},
+	&ast.ProcedureInvocation{},
+	&ast.YieldItems{},
+	&ast.YieldItem{},
+	&ast.MergeClause{},
+	&ast.MergeAction{},
+	&ast.ForeachClause{},
+	&ast.SetClause{},
+	&ast.PropertySetItem{},
+	&ast.MatchClause{},
+	&ast.UnwindClause{},
+	&ast.LoadCSVClause{},
+	&ast.VariableSetItem{},
+	&ast.VariableAddSetItem{},
+	&ast.LabelsSetItem{},
+	&ast.RemoveClause{},
+	&ast.LabelsRemoveItem{},
+	&ast.PropertyRemoveItem{},
+	&ast.DeleteClause{},
+	&ast.CreateIndex{},
+	&ast.DropIndex{},
+	&ast.IndexDefinition{},
+	&ast.CreateConstraint{},
+	&ast.DropConstraint{},
+	&ast.ConstraintDefinition{},
+	&ast.Pattern{},
+	&ast.PatternElementNested{},
+	&ast.PatternElementSequence{},
+	&ast.PatternElementPattern{},
+	&ast.ShortestPathPattern{},
+	&ast.PatternPart{},
+	&ast.PathSelector{},
+	&ast.Projection{},
+	&ast.ProjectionItems{},
+	&ast.ProjectionItem{},
+	&ast.SortOrder{},
+	&ast.SortItem{},
+	&ast.OpExpr{},
+	&ast.TypePredicateExpr{},
+	&ast.SimpleType{},
+	&ast.ListType{},
+	&ast.UnionType{},
+	&ast.UnaryExpr{},
+	&ast.BinaryExpr{},
+	&ast.TernaryExpr{},
+	&ast.ListExpr{},
+	&ast.ListComprehensionExpr{},
+	&ast.PropertyLabelsExpr{},
+	&ast.SymbolicNameSchemaName{},
+	&ast.ReservedWordSchemaName{},
+	&ast.LabelName{},
+	&ast.LabelWildcard{},
+	&ast.LabelNot{},
+	&ast.LabelAnd{},
+	&ast.LabelOr{},
+	&ast.SymbolicNameIdentifier{},
+	&ast.SymbolicNameHexLetter{},
+	&ast.ReservedWord{},
+	&ast.Label{},
+	&ast.PrimitiveLiteral{},
+	&ast.ListLiteral{},
+	&ast.Parameter{},
+	&ast.CaseExpr{},
+	&ast.CaseAltNode{},
+	&ast.QuantifierExpr{},
+	&ast.FilterExpr{},
+	&ast.VariableExpr{},
+	&ast.PatternComprehensionExpr{},
+	&ast.NodePattern{},
+	&ast.MapLiteral{},
+	&ast.PropertyKeyName{},
+	&ast.MapProjectionExpr{},
+	&ast.PropertySelector{},
+	&ast.AllPropertiesSelector{},
+	&ast.LiteralEntrySelector{},
+	&ast.VariableSelector{},
+	&ast.Properties{},
+	&ast.RelationshipsPattern{},
+	&ast.PatternElementChain{},
+	&ast.RelationshipPattern{},
+	&ast.RelationshipDetail{},
+	&ast.RangeLiteral{},
+	&ast.FunctionInvocation{},
+	&ast.SymbolicFunctionName{},
+	&ast.ListOperatorExpr{},
+	&ast.ExistsSubqueryExpr{},
+	&ast.CountSubqueryExpr{},
+	&ast.ExistsFunctionName{},
+}
+
+// nodeTypes are the node types by name.
+var nodeTypes = map[string]reflect.Type{}
+
+// enumValues are the values of enumerations by name.
+var enumValues = map[reflect.Type]map[string]int{}
+
+func init() {
+	for _, node := range nodes {
+		t := reflect.TypeOf(node).Elem()
+		nodeTypes[t.Name()] = t
+	}
+	for t, names := range enumNames {
+		values := map[string]int{}
+		for value, name := range names {
+			values[name] = value
+		}
+		enumValues[t] = values
+	}
+}
+
+var (
+	nodeInterface  = reflect.TypeOf((*ast.Node)(nil)).Elem()
+	nodeSpanType   = reflect.TypeOf(ast.NodeSpan{})
+	tokenType      = reflect.TypeOf(scanner.Token{})
+	tokenTypeType  = reflect.TypeOf(scanner.TokenType(0))
+	runeType       = reflect.TypeOf(rune(0))
+	emptyInterface = reflect.TypeOf((*any)(nil)).Elem()
+)
+
+type document struct {
+	Version int             `json:"version"`
+	AST     json.RawMessage `json:"ast"`
+}
+
+// Marshal returns the JSON encoding of node.
+func Marshal(node ast.Node) ([]byte, error) {
+	e := &encoder{}
+	e.b.WriteString(`{"version":`)
+	fmt.Fprint(&e.b, Version)
+	e.b.WriteString(`,"ast":`)
+	if err := e.value(reflect.ValueOf(&node).Elem()); err != nil {
+		return nil, err
+	}
+	e.b.WriteString("}")
+	return e.b.Bytes(), nil
+}
+
+// Unmarshal returns the node encoded by Marshal in data.
+func Unmarshal(data []byte) (ast.Node, error) {
+	doc := document{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("astjson: %w", err)
+	}
+	if doc.Version != Version {
+		return nil, fmt.Errorf("astjson: unsupported version %d, expecting %d", doc.Version, Version)
+	}
+	d := json.NewDecoder(bytes.NewReader(doc.AST))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("astjson: %w", err)
+	}
+	node, err := decode(nodeInterface, v)
+	if err != nil {
+		return nil, err
+	}
+	if node.IsNil() {
+		return nil, nil
+	}
+	return node.Interface().(ast.Node), nil
+}
+
+type encoder struct {
+	b bytes.Buffer
+}
+
+func (e *encoder) value(v reflect.Value) error {
+	t := v.Type()
+	if names, ok := enumNames[t]; ok {
+		name, ok := names[int(v.Int())]
+		if !ok {
+			return fmt.Errorf("astjson: invalid %s %d", t, v.Int())
+		}
+		return e.scalar(name)
+	}
+	switch {
+	case t == tokenType:
+		return e.token(v.Interface().(scanner.Token))
+	case t == runeType:
+		return e.scalar(string(rune(v.Int())))
+	}
+	switch v.Kind() {
+	case reflect.Interface, reflect.Pointer:
+		if v.IsNil() {
+			e.b.WriteString("null")
+			return nil
+		}
+		if v.Kind() == reflect.Pointer && t.Implements(nodeInterface) {
+			return e.node(v)
+		}
+		return e.value(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			e.b.WriteString("null")
+			return nil
+		}
+		e.b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				e.b.WriteString(",")
+			}
+			if err := e.value(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		e.b.WriteString("]")
+		return nil
+	case reflect.Bool, reflect.String, reflect.Int64, reflect.Int, reflect.Float64:
+		return e.scalar(v.Interface())
+	}
+	return fmt.Errorf("astjson: cannot encode %s", t)
+}
+
+func (e *encoder) scalar(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("astjson: %w", err)
+	}
+	e.b.Write(b)
+	return nil
+}
+
+func (e *encoder) node(v reflect.Value) error {
+	t := v.Elem().Type()
+	if _, ok := nodeTypes[t.Name()]; !ok {
+		return fmt.Errorf("astjson: cannot encode %s", t)
+	}
+	e.b.WriteString(`{"type":`)
+	e.scalar(t.Name())
+	e.b.WriteString(`,"span":`)
+	e.span(v.Interface().(ast.Node).Span())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type == nodeSpanType {
+			continue
+		}
+		e.b.WriteString(",")
+		e.scalar(memberName(field))
+		e.b.WriteString(":")
+		if err := e.value(v.Elem().Field(i)); err != nil {
+			return err
+		}
+	}
+	e.b.WriteString("}")
+	return nil
+}
+
+func (e *encoder) span(span scanner.Span) {
+	fmt.Fprintf(&e.b, `{"start":{"offset":%d,"line":%d,"column":%d},"end":{"offset":%d,"line":%d,"column":%d}}`,
+		span.Start.Offset, span.Start.Line, span.Start.Column, span.End.Offset, span.End.Line, span.End.Column)
+}
+
+func (e *encoder) token(t scanner.Token) error {
+	e.b.WriteString(`{"tokenType":`)
+	if err := e.value(reflect.ValueOf(t.T)); err != nil {
+		return err
+	}
+	e.b.WriteString(`,"lexeme":`)
+	e.scalar(t.Lexeme)
+	e.b.WriteString(`,"literal":`)
+	if err := e.scalar(t.Literal); err != nil {
+		return err
+	}
+	fmt.Fprintf(&e.b, `,"line":%d,"span":`, t.Line)
+	e.span(t.Span)
+	e.b.WriteString("}")
+	return nil
+}
+
+// memberName returns the name of the member holding a field, which is the field name starting with a lower
+// case letter, or with its leading initialism in lower case, as in "url". A field named Type is held in a
+// member named after its type, such as "valueType", as "type" holds the type of the node.
+func memberName(field reflect.StructField) string {
+	name := field.Name
+	if name == "Type" {
+		name = field.Type.Name()
+	}
+	runes := []rune(name)
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			break
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+	return string(runes)
+}
+
+// decode returns the value of type t encoded as v, which is a value decoded by encoding/json with numbers
+// kept as json.Number.
+func decode(t reflect.Type, v any) (reflect.Value, error) {
+	if values, ok := enumValues[t]; ok {
+		name, ok := v.(string)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("astjson: expecting %s name, got %v", t, v)
+		}
+		value, ok := values[name]
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("astjson: unknown %s %q", t, name)
+		}
+		return reflect.ValueOf(value).Convert(t), nil
+	}
+	switch {
+	case t == tokenType:
+		return decodeToken(v)
+	case t == runeType:
+		s, ok := v.(string)
+		if r, size := utf8.DecodeRuneInString(s); !ok || size == 0 || size != len(s) {
+			return reflect.Value{}, fmt.Errorf("astjson: expecting a single character, got %v", v)
+		} else {
+			return reflect.ValueOf(r), nil
+		}
+	case t == emptyInterface:
+		if v == nil {
+			return reflect.Zero(t), nil
+		}
+		return reflect.ValueOf(&v).Elem(), nil
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		if v == nil {
+			return reflect.Zero(t), nil
+		}
+		node, err := decodeNode(v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if !node.Type().AssignableTo(t) {
+			return reflect.Value{}, fmt.Errorf("astjson: %s is not a %s", node.Elem().Type().Name(), t)
+		}
+		value := reflect.New(t).Elem()
+		value.Set(node)
+		return value, nil
+	case reflect.Pointer:
+		if v == nil {
+			return reflect.Zero(t), nil
+		}
+		if t.Implements(nodeInterface) {
+			node, err := decodeNode(v)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			if node.Type() != t {
+				return reflect.Value{}, fmt.Errorf("astjson: %s is not a %s", node.Elem().Type().Name(), t.Elem().Name())
+			}
+			return node, nil
+		}
+		elem, err := decode(t.Elem(), v)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(t.Elem())
+		ptr.Elem().Set(elem)
+		return ptr, nil
+	case reflect.Slice:
+		if v == nil {
+			return reflect.Zero(t), nil
+		}
+		items, ok := v.([]any)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("astjson: expecting a list of %s, got %v", t.Elem(), v)
+		}
+		slice := reflect.MakeSlice(t, len(items), len(items))
+		for i, item := range items {
+			elem, err := decode(t.Elem(), item)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			slice.Index(i).Set(elem)
+		}
+		return slice, nil
+	case reflect.Bool:
+		if b, ok := v.(bool); ok {
+			return reflect.ValueOf(b), nil
+		}
+	case reflect.String:
+		if s, ok := v.(string); ok {
+			return reflect.ValueOf(s), nil
+		}
+	case reflect.Int64, reflect.Int:
+		if n, ok := v.(json.Number); ok {
+			if i, err := n.Int64(); err == nil {
+				return reflect.ValueOf(i).Convert(t), nil
+			}
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("astjson: expecting %s, got %v", t, v)
+}
+
+// decodeNode returns a pointer to the node encoded as v.
+func decodeNode(v any) (reflect.Value, error) {
+	members, ok := v.(map[string]any)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("astjson: expecting a node, got %v", v)
+	}
+	name, _ := members["type"].(string)
+	t, ok := nodeTypes[name]
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("astjson: unknown node type %q", name)
+	}
+	node := reflect.New(t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Type == nodeSpanType {
+			continue
+		}
+		value, err := decode(field.Type, members[memberName(field)])
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		node.Elem().Field(i).Set(value)
+	}
+	span, err := decodeSpan(members["span"])
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	node.Interface().(interface{ SetSpan(ast.Span) }).SetSpan(span)
+	if literal, ok := node.Interface().(*ast.PrimitiveLiteral); ok {
+		if literal.Value, err = literalValue(literal.Kind, literal.Value); err != nil {
+			return reflect.Value{}, err
+		}
+	}
+	return node, nil
+}
+
+func decodeToken(v any) (reflect.Value, error) {
+	members, ok := v.(map[string]any)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("astjson: expecting a token, got %v", v)
+	}
+	t := scanner.Token{}
+	tokenType, err := decode(tokenTypeType, members["tokenType"])
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	t.T = tokenType.Interface().(scanner.TokenType)
+	if t.Lexeme, ok = members["lexeme"].(string); !ok {
+		return reflect.Value{}, fmt.Errorf("astjson: expecting token lexeme, got %v", members["lexeme"])
+	}
+	if t.Literal, err = literalValue(t.T, members["literal"]); err != nil {
+		return reflect.Value{}, err
+	}
+	line, err := decode(reflect.TypeOf(0), members["line"])
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	t.Line = int(line.Int())
+	if t.Span, err = decodeSpan(members["span"]); err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(t), nil
+}
+
+func decodeSpan(v any) (scanner.Span, error) {
+	span := scanner.Span{}
+	if v == nil {
+		return span, nil
+	}
+	members, ok := v.(map[string]any)
+	if !ok {
+		return span, fmt.Errorf("astjson: expecting a span, got %v", v)
+	}
+	var err error
+	if span.Start, err = decodePos(members["start"]); err != nil {
+		return span, err
+	}
+	span.End, err = decodePos(members["end"])
+	return span, err
+}
+
+func decodePos(v any) (scanner.Pos, error) {
+	members, ok := v.(map[string]any)
+	if !ok {
+		return scanner.Pos{}, fmt.Errorf("astjson: expecting a position, got %v", v)
+	}
+	values := [3]int{}
+	for i, name := range []string{"offset", "line", "column"} {
+		n, ok := members[name].(json.Number)
+		if !ok {
+			return scanner.Pos{}, fmt.Errorf("astjson: expecting position %s, got %v", name, members[name])
+		}
+		value, err := n.Int64()
+		if err != nil {
+			return scanner.Pos{}, fmt.Errorf("astjson: %w", err)
+		}
+		values[i] = int(value)
+	}
+	return scanner.Pos{Offset: values[0], Line: values[1], Column: values[2]}, nil
+}
+
+// literalValue returns the value of a literal or token of kind t encoded as v: an int64 for integers, a
+// float64 for doubles, and as decoded otherwise.
+func literalValue(t scanner.TokenType, v any) (any, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v, nil
+	}
+	switch t {
+	case scanner.Integer, scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger:
+		i, err := n.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("astjson: %w", err)
+		}
+		return i, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("astjson: %w", err)
+	}
+	return f, nil
+}
@@ -0,0 +1,159 @@
+package astjson
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/parser"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/mburbidg/cypher/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+type testReporter struct{}
+
+func (r testReporter) Error(line int, msg string) error {
+	return utils.ParseError{Line: line, Msg: msg}
+}
+
+func parse(t *testing.T, src string) ast.Statement {
+	reporter := testReporter{}
+	p := parser.New(scanner.New([]byte(src), reporter), reporter)
+	stmt, err := p.Parse()
+	require.NoError(t, err)
+	return stmt.AST
+}
+
+// queries are the string literals in the parser tests that start with a clause or a schema command.
+var queries = regexp.MustCompile(`"(?:MATCH|OPTIONAL|CREATE|MERGE|RETURN|WITH|UNWIND|CALL|FOREACH|LOAD|DROP|UNION)\b(?:[^"\\]|\\.)*"`)
+
+func TestRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("../parser/*_test.go")
+	require.NoError(t, err)
+	count := 0
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		require.NoError(t, err)
+		for _, literal := range queries.FindAllString(string(src), -1) {
+			query, err := strconv.Unquote(literal)
+			require.NoError(t, err)
+			reporter := testReporter{}
+			stmt, err := parser.New(scanner.New([]byte(query), reporter), reporter).Parse()
+			if err != nil {
+				continue
+			}
+			data, err := Marshal(stmt.AST)
+			require.NoError(t, err, query)
+			node, err := Unmarshal(data)
+			require.NoError(t, err, query)
+			assert.Equal(t, stmt.AST, node, query)
+			count++
+		}
+	}
+	assert.Greater(t, count, 300)
+}
+
+func TestRoundTripValues(t *testing.T) {
+	tests := map[string]struct {
+		src string
+	}{
+		"literals":       {"RETURN 1.5, 0x1F, 017, 'a', true, false, null, $0, $p"},
+		"ranges":         {"MATCH p = ANY SHORTEST (a)-[:R*]->(b)-[*2..]->(c) RETURN p"},
+		"types":          {"RETURN x IS :: LIST<INTEGER | STRING> NOT NULL, y IS NOT :: ANY<FLOAT>"},
+		"labels":         {"MATCH (n:!A&(B|%)) WHERE n:C:D RETURN n"},
+		"schema command": {"CREATE CONSTRAINT c IF NOT EXISTS FOR (n:L) REQUIRE (n.a, n.b) IS UNIQUE"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stmt := parse(t, tc.src)
+			data, err := Marshal(stmt)
+			require.NoError(t, err)
+			node, err := Unmarshal(data)
+			require.NoError(t, err)
+			assert.Equal(t, stmt, node)
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	data, err := Marshal(parse(t, "RETURN -$x"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": 1,
+		"ast": {
+			"type": "SinglePartQuery",
+			"span": {"start": {"offset": 0, "line": 1, "column": 1}, "end": {"offset": 10, "line": 1, "column": 11}},
+			"readingClause": [],
+			"updatingClause": [],
+			"projection": {
+				"type": "Projection",
+				"span": {"start": {"offset": 0, "line": 1, "column": 1}, "end": {"offset": 10, "line": 1, "column": 11}},
+				"distinct": false,
+				"items": {
+					"type": "ProjectionItems",
+					"span": {"start": {"offset": 7, "line": 1, "column": 8}, "end": {"offset": 10, "line": 1, "column": 11}},
+					"all": false,
+					"items": [{
+						"type": "ProjectionItem",
+						"span": {"start": {"offset": 7, "line": 1, "column": 8}, "end": {"offset": 10, "line": 1, "column": 11}},
+						"expr": {
+							"type": "UnaryExpr",
+							"span": {"start": {"offset": 7, "line": 1, "column": 8}, "end": {"offset": 10, "line": 1, "column": 11}},
+							"op": "Negate",
+							"expr": {
+								"type": "PropertyLabelsExpr",
+								"span": {"start": {"offset": 8, "line": 1, "column": 9}, "end": {"offset": 10, "line": 1, "column": 11}},
+								"atom": {
+									"type": "Parameter",
+									"span": {"start": {"offset": 8, "line": 1, "column": 9}, "end": {"offset": 10, "line": 1, "column": 11}},
+									"symbolicName": {
+										"type": "SymbolicNameIdentifier",
+										"span": {"start": {"offset": 9, "line": 1, "column": 10}, "end": {"offset": 10, "line": 1, "column": 11}},
+										"identifier": {
+											"tokenType": "Identifier",
+											"lexeme": "x",
+											"literal": null,
+											"line": 1,
+											"span": {"start": {"offset": 9, "line": 1, "column": 10}, "end": {"offset": 10, "line": 1, "column": 11}}
+										},
+										"symbolType": "Identifier"
+									},
+									"n": null
+								},
+								"propertyKeys": null,
+								"labels": [],
+								"labelExpr": null
+							}
+						},
+						"variable": null
+					}]
+				},
+				"order": null,
+				"skip": null,
+				"limit": null
+			}
+		}
+	}`, string(data))
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := map[string]struct {
+		data string
+	}{
+		"version":      {`{"version": 0, "ast": null}`},
+		"unknown type": {`{"version": 1, "ast": {"type": "Unknown"}}`},
+		"wrong type":   {`{"version": 1, "ast": {"type": "UnaryExpr", "op": "Negate", "expr": {"type": "Pattern"}}}`},
+		"operator":     {`{"version": 1, "ast": {"type": "UnaryExpr", "op": "Plus"}}`},
+		"invalid":      {`{"version": 1, "ast": [}`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Unmarshal([]byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+}
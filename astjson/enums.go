@@ -0,0 +1,188 @@
+package astjson
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"reflect"
+)
+
+// enumNames are the names enumerated values are encoded as, which are the names of their constants.
+var enumNames = map[reflect.Type]map[int]string{
+	reflect.TypeOf(ast.Operator(0)): {
+		int(ast.Xor):                "Xor",
+		int(ast.And):                "And",
+		int(ast.Or):                 "Or",
+		int(ast.Not):                "Not",
+		int(ast.Negate):             "Negate",
+		int(ast.IsNull):             "IsNull",
+		int(ast.IsNotNull):          "IsNotNull",
+		int(ast.StringOrListOp):     "StringOrListOp",
+		int(ast.StartsWith):         "StartsWith",
+		int(ast.EndsWith):           "EndsWith",
+		int(ast.Contains):           "Contains",
+		int(ast.InList):             "InList",
+		int(ast.ListIndex):          "ListIndex",
+		int(ast.ListRange):          "ListRange",
+		int(ast.CountAll):           "CountAll",
+		int(ast.AllOp):              "AllOp",
+		int(ast.AnyOp):              "AnyOp",
+		int(ast.NoneOp):             "NoneOp",
+		int(ast.SingleOp):           "SingleOp",
+		int(ast.Equal):              "Equal",
+		int(ast.NotEqual):           "NotEqual",
+		int(ast.LessThan):           "LessThan",
+		int(ast.GreaterThan):        "GreaterThan",
+		int(ast.LessThanOrEqual):    "LessThanOrEqual",
+		int(ast.GreaterThanOrEqual): "GreaterThanOrEqual",
+		int(ast.Add):                "Add",
+		int(ast.Subtract):           "Subtract",
+		int(ast.Multiply):           "Multiply",
+		int(ast.Divide):             "Divide",
+		int(ast.Modulo):             "Modulo",
+		int(ast.PowerOf):            "PowerOf",
+	},
+	reflect.TypeOf(ast.ValueType(0)): {
+		int(ast.AnyValue):           "AnyValue",
+		int(ast.NothingValue):       "NothingValue",
+		int(ast.NullValue):          "NullValue",
+		int(ast.BooleanValue):       "BooleanValue",
+		int(ast.StringValue):        "StringValue",
+		int(ast.IntegerValue):       "IntegerValue",
+		int(ast.FloatValue):         "FloatValue",
+		int(ast.DateValue):          "DateValue",
+		int(ast.LocalTimeValue):     "LocalTimeValue",
+		int(ast.ZonedTimeValue):     "ZonedTimeValue",
+		int(ast.LocalDateTimeValue): "LocalDateTimeValue",
+		int(ast.ZonedDateTimeValue): "ZonedDateTimeValue",
+		int(ast.DurationValue):      "DurationValue",
+		int(ast.PointValue):         "PointValue",
+		int(ast.NodeValue):          "NodeValue",
+		int(ast.RelationshipValue):  "RelationshipValue",
+		int(ast.PathValue):          "PathValue",
+		int(ast.MapValue):           "MapValue",
+		int(ast.PropertyValue):      "PropertyValue",
+	},
+	reflect.TypeOf(ast.Relationship(0)): {
+		int(ast.Directed):   "Directed",
+		int(ast.Undirected): "Undirected",
+	},
+	reflect.TypeOf(ast.Order(0)): {
+		int(ast.Asc):  "Asc",
+		int(ast.Desc): "Desc",
+	},
+	reflect.TypeOf(ast.MergeActionType(0)): {
+		int(ast.OnMatch):  "OnMatch",
+		int(ast.OnCreate): "OnCreate",
+	},
+	reflect.TypeOf(ast.ConstraintType(0)): {
+		int(ast.UniqueConstraint):    "UniqueConstraint",
+		int(ast.ExistenceConstraint): "ExistenceConstraint",
+	},
+	reflect.TypeOf(ast.PathSelectorKind(0)): {
+		int(ast.AnyShortestPath):  "AnyShortestPath",
+		int(ast.AllShortestPaths): "AllShortestPaths",
+		int(ast.ShortestKPaths):   "ShortestKPaths",
+	},
+	reflect.TypeOf(ast.SymbolType(0)): {
+		int(ast.Count):      "Count",
+		int(ast.Filter):     "Filter",
+		int(ast.Extract):    "Extract",
+		int(ast.Any):        "Any",
+		int(ast.None):       "None",
+		int(ast.Single):     "Single",
+		int(ast.Identifier): "Identifier",
+	},
+	reflect.TypeOf(scanner.TokenType(0)): {
+		int(scanner.Illegal):            "Illegal",
+		int(scanner.EndOfInput):         "EndOfInput",
+		int(scanner.None):               "None",
+		int(scanner.Period):             "Period",
+		int(scanner.Comma):              "Comma",
+		int(scanner.Dotdot):             "Dotdot",
+		int(scanner.OpenParen):          "OpenParen",
+		int(scanner.CloseParen):         "CloseParen",
+		int(scanner.OpenBrace):          "OpenBrace",
+		int(scanner.CloseBrace):         "CloseBrace",
+		int(scanner.OpenBracket):        "OpenBracket",
+		int(scanner.CloseBracket):       "CloseBracket",
+		int(scanner.Plus):               "Plus",
+		int(scanner.Dash):               "Dash",
+		int(scanner.Star):               "Star",
+		int(scanner.ForwardSlash):       "ForwardSlash",
+		int(scanner.Percent):            "Percent",
+		int(scanner.Caret):              "Caret",
+		int(scanner.Equal):              "Equal",
+		int(scanner.NotEqual):           "NotEqual",
+		int(scanner.LessThan):           "LessThan",
+		int(scanner.GreaterThan):        "GreaterThan",
+		int(scanner.LessThanOrEqual):    "LessThanOrEqual",
+		int(scanner.GreaterThanOrEqual): "GreaterThanOrEqual",
+		int(scanner.DollarSign):         "DollarSign",
+		int(scanner.Colon):              "Colon",
+		int(scanner.Pipe):               "Pipe",
+		int(scanner.PlusEqual):          "PlusEqual",
+		int(scanner.Ampersand):          "Ampersand",
+		int(scanner.Exclamation):        "Exclamation",
+		int(scanner.DoubleColon):        "DoubleColon",
+		int(scanner.Identifier):         "Identifier",
+		int(scanner.Double):             "Double",
+		int(scanner.Integer):            "Integer",
+		int(scanner.DecimalInteger):     "DecimalInteger",
+		int(scanner.HexInteger):         "HexInteger",
+		int(scanner.OctInteger):         "OctInteger",
+		int(scanner.String):             "String",
+		int(scanner.All):                "All",
+		int(scanner.Asc):                "Asc",
+		int(scanner.Ascending):          "Ascending",
+		int(scanner.By):                 "By",
+		int(scanner.Create):             "Create",
+		int(scanner.Delete):             "Delete",
+		int(scanner.Desc):               "Desc",
+		int(scanner.Descending):         "Descending",
+		int(scanner.Detach):             "Detach",
+		int(scanner.Exists):             "Exists",
+		int(scanner.Limit):              "Limit",
+		int(scanner.Match):              "Match",
+		int(scanner.Merge):              "Merge",
+		int(scanner.On):                 "On",
+		int(scanner.Optional):           "Optional",
+		int(scanner.Order):              "Order",
+		int(scanner.Remove):             "Remove",
+		int(scanner.Return):             "Return",
+		int(scanner.Set):                "Set",
+		int(scanner.Skip):               "Skip",
+		int(scanner.Where):              "Where",
+		int(scanner.With):               "With",
+		int(scanner.Union):              "Union",
+		int(scanner.Unwind):             "Unwind",
+		int(scanner.And):                "And",
+		int(scanner.As):                 "As",
+		int(scanner.Contains):           "Contains",
+		int(scanner.Distinct):           "Distinct",
+		int(scanner.Ends):               "Ends",
+		int(scanner.In):                 "In",
+		int(scanner.Is):                 "Is",
+		int(scanner.Not):                "Not",
+		int(scanner.Or):                 "Or",
+		int(scanner.Starts):             "Starts",
+		int(scanner.Xor):                "Xor",
+		int(scanner.False):              "False",
+		int(scanner.True):               "True",
+		int(scanner.Null):               "Null",
+		int(scanner.Constraint):         "Constraint",
+		int(scanner.Do):                 "Do",
+		int(scanner.For):                "For",
+		int(scanner.Require):            "Require",
+		int(scanner.Unique):             "Unique",
+		int(scanner.Case):               "Case",
+		int(scanner.When):               "When",
+		int(scanner.Then):               "Then",
+		int(scanner.Else):               "Else",
+		int(scanner.End):                "End",
+		int(scanner.Mandatory):          "Mandatory",
+		int(scanner.Scalar):             "Scalar",
+		int(scanner.Of):                 "Of",
+		int(scanner.Add):                "Add",
+		int(scanner.Drop):               "Drop",
+	},
+}
@@ -0,0 +1,170 @@
+// Package cst provides a concrete syntax tree: the AST of a statement together with every token of its
+// source, and the whitespace and comments between them. The tree reproduces the source byte for byte, so a
+// tool can rewrite part of a query and keep the rest of it, comments included, as it was written.
+package cst
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Element is either a *Node or a *Token.
+type Element interface {
+	// writeTo writes the source text of the element, with the trivia preceding its tokens.
+	writeTo(b *strings.Builder)
+}
+
+// Node is a node of the AST, and the elements it was parsed from in source order: the tokens of the node
+// itself, such as its keywords and punctuation, and the nodes of its children.
+type Node struct {
+	Node     ast.Node
+	Children []Element
+}
+
+// Token is a token scanned by a lossless scanner, with the trivia preceding it.
+type Token struct {
+	scanner.Token
+
+	// The source text of the token.
+	Text string
+}
+
+// Text returns the source text of n, with the trivia preceding its tokens.
+func (n *Node) Text() string {
+	b := strings.Builder{}
+	n.writeTo(&b)
+	return b.String()
+}
+
+func (n *Node) writeTo(b *strings.Builder) {
+	for _, child := range n.Children {
+		child.writeTo(b)
+	}
+}
+
+func (t *Token) writeTo(b *strings.Builder) {
+	for _, trivia := range t.Trivia {
+		b.WriteString(trivia.Text)
+	}
+	b.WriteString(t.Text)
+}
+
+// Tokens returns the tokens of n and its descendants in source order.
+func (n *Node) Tokens() []*Token {
+	tokens := []*Token{}
+	for _, child := range n.Children {
+		switch child := child.(type) {
+		case *Node:
+			tokens = append(tokens, child.Tokens()...)
+		case *Token:
+			tokens = append(tokens, child)
+		}
+	}
+	return tokens
+}
+
+// Find returns the node of the tree rooted at n for the AST node target, or nil if there is none.
+func (n *Node) Find(target ast.Node) *Node {
+	if n.Node == target {
+		return n
+	}
+	for _, child := range n.Children {
+		if child, ok := child.(*Node); ok {
+			if found := child.Find(target); found != nil {
+				return found
+			}
+		}
+	}
+	return nil
+}
+
+// Build returns the tree for the AST root, which was parsed from the source scanned by s. The scanner must
+// be lossless and positioned at the start of the source. Tokens that are not part of any node, such as
+// EndOfInput, are children of the root, so the tree always reproduces the whole source.
+func Build(root ast.Node, s *scanner.Scanner) *Node {
+	tokens := []*Token{}
+	for {
+		t := s.NextToken()
+		tokens = append(tokens, &Token{Token: t, Text: s.Text(t)})
+		if t.T == scanner.EndOfInput {
+			break
+		}
+	}
+	return build(root, tokens)
+}
+
+// build returns the node for n, given the tokens within its span. Each token belongs to the innermost
+// node whose span contains it.
+func build(n ast.Node, tokens []*Token) *Node {
+	node := &Node{Node: n}
+	i := 0
+	for _, child := range children(n) {
+		span := child.Span()
+		for i < len(tokens) && tokens[i].Span.Start.Offset < span.Start.Offset {
+			node.Children = append(node.Children, tokens[i])
+			i++
+		}
+		j := i
+		for j < len(tokens) && tokens[j].Span.Start.Offset < span.End.Offset && tokens[j].Span.End.Offset <= span.End.Offset {
+			j++
+		}
+		if j > i {
+			node.Children = append(node.Children, build(child, tokens[i:j]))
+			i = j
+		}
+	}
+	for ; i < len(tokens); i++ {
+		node.Children = append(node.Children, tokens[i])
+	}
+	return node
+}
+
+var nodeType = reflect.TypeOf((*ast.Node)(nil)).Elem()
+
+// children returns the children of n that were parsed from source text, in source order. The children of
+// a child without a span, which the parser built rather than parsed, take its place.
+func children(n ast.Node) []ast.Node {
+	nodes := []ast.Node{}
+	var add func(v reflect.Value)
+	add = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Interface:
+			add(v.Elem())
+		case reflect.Pointer:
+			if v.IsNil() {
+				return
+			}
+			if v.Type().Implements(nodeType) {
+				child := v.Interface().(ast.Node)
+				if span := child.Span(); span.End.Offset > span.Start.Offset {
+					nodes = append(nodes, child)
+				} else {
+					nodes = append(nodes, children(child)...)
+				}
+				return
+			}
+			add(v.Elem())
+		case reflect.Slice:
+			for i := 0; i < v.Len(); i++ {
+				add(v.Index(i))
+			}
+		case reflect.Struct:
+			for i := 0; i < v.NumField(); i++ {
+				if v.Type().Field(i).IsExported() {
+					add(v.Field(i))
+				}
+			}
+		}
+	}
+	v := reflect.ValueOf(n)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		add(v.Elem())
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Span().Start.Offset < nodes[j].Span().Start.Offset
+	})
+	return nodes
+}
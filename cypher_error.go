@@ -9,6 +9,10 @@ const (
 	RequiresDirectedRelationship = "RequiresDirectedRelationship"
 	CreatingVarLength            = "CreatingVarLength"
 	InvalidParameterUse          = "InvalidParameterUse"
+	DifferentColumnsInUnion      = "DifferentColumnsInUnion"
+	InvalidClauseComposition     = "InvalidClauseComposition"
+	InvalidDelete                = "InvalidDelete"
+	InvalidArgumentType          = "InvalidArgumentType"
 )
 
 type CypherErr struct {
@@ -61,3 +65,31 @@ func NewInvalidParameterUse() error {
 		Code: InvalidParameterUse,
 	}
 }
+
+func NewDifferentColumnsInUnion() error {
+	return &CypherErr{
+		Msg:  fmt.Sprintf("different columns in union"),
+		Code: DifferentColumnsInUnion,
+	}
+}
+
+func NewInvalidClauseComposition() error {
+	return &CypherErr{
+		Msg:  fmt.Sprintf("invalid clause composition"),
+		Code: InvalidClauseComposition,
+	}
+}
+
+func NewInvalidDelete() error {
+	return &CypherErr{
+		Msg:  fmt.Sprintf("invalid delete"),
+		Code: InvalidDelete,
+	}
+}
+
+func NewInvalidArgumentType() error {
+	return &CypherErr{
+		Msg:  fmt.Sprintf("invalid argument type"),
+		Code: InvalidArgumentType,
+	}
+}
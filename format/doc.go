@@ -0,0 +1,130 @@
+package format
+
+import "strings"
+
+// A doc is laid out as text by render. Groups are printed on one line when they fit within the line width,
+// otherwise each line within them starts a new line.
+type doc interface{}
+
+// text is printed as is.
+type text string
+
+// line is printed as flat when its group fits on one line, otherwise as a new line.
+type line struct {
+	flat string
+}
+
+// hardline is always printed as a new line.
+type hardline struct{}
+
+// nest indents the lines within its doc one level further.
+type nest struct {
+	doc doc
+}
+
+// group is printed on one line if it fits.
+type group struct {
+	doc doc
+}
+
+type docs []doc
+
+var (
+	space    = line{flat: " "}
+	softline = line{}
+)
+
+// join returns items separated by sep.
+func join(items []doc, sep ...doc) doc {
+	joined := docs{}
+	for i, item := range items {
+		if i > 0 {
+			joined = append(joined, sep...)
+		}
+		joined = append(joined, item)
+	}
+	return joined
+}
+
+// layout is a doc waiting to be printed, with the indentation level and mode it is printed with.
+type layout struct {
+	indent int
+	flat   bool
+	doc    doc
+}
+
+func render(d doc, options Options) string {
+	b := strings.Builder{}
+	column := 0
+	newline := func(indent int) {
+		b.WriteByte('\n')
+		b.WriteString(strings.Repeat(options.Indent, indent))
+		column = len(options.Indent) * indent
+	}
+	stack := []layout{{doc: d}}
+	for len(stack) > 0 {
+		l := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch d := l.doc.(type) {
+		case nil:
+		case text:
+			b.WriteString(string(d))
+			column += len(d)
+		case docs:
+			for i := len(d) - 1; i >= 0; i-- {
+				stack = append(stack, layout{indent: l.indent, flat: l.flat, doc: d[i]})
+			}
+		case nest:
+			stack = append(stack, layout{indent: l.indent + 1, flat: l.flat, doc: d.doc})
+		case group:
+			flat := l.flat || options.LineWidth <= 0 || fits(options.LineWidth-column, d.doc, stack)
+			stack = append(stack, layout{indent: l.indent, flat: flat, doc: d.doc})
+		case line:
+			if l.flat {
+				b.WriteString(d.flat)
+				column += len(d.flat)
+			} else {
+				newline(l.indent)
+			}
+		case hardline:
+			newline(l.indent)
+		}
+	}
+	return b.String()
+}
+
+// fits reports whether d, printed on one line, and what follows it up to the next new line, fit within width.
+func fits(width int, d doc, rest []layout) bool {
+	stack := []layout{{flat: true, doc: d}}
+	for width >= 0 {
+		if len(stack) == 0 {
+			if len(rest) == 0 {
+				return true
+			}
+			stack = append(stack, rest[len(rest)-1])
+			rest = rest[:len(rest)-1]
+		}
+		l := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		switch d := l.doc.(type) {
+		case text:
+			width -= len(d)
+		case docs:
+			for i := len(d) - 1; i >= 0; i-- {
+				stack = append(stack, layout{flat: l.flat, doc: d[i]})
+			}
+		case nest:
+			stack = append(stack, layout{flat: l.flat, doc: d.doc})
+		case group:
+			stack = append(stack, layout{flat: true, doc: d.doc})
+		case line:
+			if !l.flat {
+				return true
+			}
+			width -= len(d.flat)
+		case hardline:
+			return true
+		}
+	}
+	return false
+}
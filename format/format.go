@@ -0,0 +1,1306 @@
+// Package format renders an AST as canonical Cypher text. Parsing the text returned by Format gives back the
+// AST it was formatted from.
+package format
+
+import (
+	"fmt"
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type Case int
+
+const (
+	UpperCase Case = iota
+	LowerCase
+)
+
+type Options struct {
+	// The case keywords are written in. Names in the query are written as they are.
+	KeywordCase Case
+
+	// The text indenting a line by one level.
+	Indent string
+
+	// The width lines are kept within where possible. With a width of zero, lines are broken only between
+	// clauses.
+	LineWidth int
+}
+
+var DefaultOptions = Options{
+	KeywordCase: UpperCase,
+	Indent:      "  ",
+	LineWidth:   80,
+}
+
+// Format returns the Cypher text of node, which is either a statement or any part of one.
+func Format(node ast.Node, options Options) (string, error) {
+	p := &printer{options: options}
+	d := p.node(node)
+	if p.err != nil {
+		return "", p.err
+	}
+	return render(d, options), nil
+}
+
+type printer struct {
+	options Options
+
+	// The depth of expressions being printed that the parser ends at a top-level '|', such as the WHERE of a
+	// list comprehension. Within them label and type unions are enclosed in brackets.
+	pipeDepth int
+
+	// The first node that could not be formatted.
+	err error
+}
+
+// The precedence of expressions, from lowest to highest.
+const (
+	precOr = iota + 1
+	precXor
+	precAnd
+	precNot
+	precComparison
+	precAdd
+	precMultiply
+	precPower
+	precUnary
+	precStringOrList
+	precPropertyLabels
+	precAtom
+)
+
+// The precedence of label expressions, from lowest to highest.
+const (
+	labelPrecOr = iota + 1
+	labelPrecAnd
+	labelPrecNot
+	labelPrecPrimary
+)
+
+var operators = map[ast.Operator]string{
+	ast.Or:                 "OR",
+	ast.Xor:                "XOR",
+	ast.And:                "AND",
+	ast.Equal:              "=",
+	ast.NotEqual:           "<>",
+	ast.LessThan:           "<",
+	ast.GreaterThan:        ">",
+	ast.LessThanOrEqual:    "<=",
+	ast.GreaterThanOrEqual: ">=",
+	ast.Add:                "+",
+	ast.Subtract:           "-",
+	ast.Multiply:           "*",
+	ast.Divide:             "/",
+	ast.Modulo:             "%",
+	ast.PowerOf:            "^",
+}
+
+var quantifiers = map[ast.Operator]string{
+	ast.AllOp:    "ALL",
+	ast.AnyOp:    "ANY",
+	ast.NoneOp:   "NONE",
+	ast.SingleOp: "SINGLE",
+}
+
+var typeNames = map[ast.ValueType]string{
+	ast.AnyValue:           "ANY",
+	ast.NothingValue:       "NOTHING",
+	ast.NullValue:          "NULL",
+	ast.BooleanValue:       "BOOLEAN",
+	ast.StringValue:        "STRING",
+	ast.IntegerValue:       "INTEGER",
+	ast.FloatValue:         "FLOAT",
+	ast.DateValue:          "DATE",
+	ast.LocalTimeValue:     "LOCAL TIME",
+	ast.ZonedTimeValue:     "ZONED TIME",
+	ast.LocalDateTimeValue: "LOCAL DATETIME",
+	ast.ZonedDateTimeValue: "ZONED DATETIME",
+	ast.DurationValue:      "DURATION",
+	ast.PointValue:         "POINT",
+	ast.NodeValue:          "NODE",
+	ast.RelationshipValue:  "RELATIONSHIP",
+	ast.PathValue:          "PATH",
+	ast.MapValue:           "MAP",
+	ast.PropertyValue:      "PROPERTY VALUE",
+}
+
+// kw returns keywords in the configured case.
+func (p *printer) kw(keywords string) doc {
+	if p.options.KeywordCase == LowerCase {
+		return text(strings.ToLower(keywords))
+	}
+	return text(keywords)
+}
+
+func (p *printer) unsupported(node ast.Node) doc {
+	if p.err == nil {
+		p.err = fmt.Errorf("format: cannot format %T", node)
+	}
+	return nil
+}
+
+// list returns items separated by commas, continuing on indented lines when they do not fit on one.
+func (p *printer) list(items []doc) doc {
+	if len(items) == 0 {
+		return nil
+	}
+	rest := docs{}
+	for _, item := range items[1:] {
+		rest = append(rest, text(","), space, item)
+	}
+	return group{docs{items[0], nest{rest}}}
+}
+
+// bracketed returns items separated by commas between open and close, one item per indented line when they
+// do not fit on one.
+func (p *printer) bracketed(open string, items []doc, close string) doc {
+	if len(items) == 0 {
+		return text(open + close)
+	}
+	return group{docs{text(open), nest{docs{softline, join(items, text(","), space)}}, softline, text(close)}}
+}
+
+// clauses returns clauses one per line.
+func (p *printer) clauses(clauses []doc) doc {
+	return join(clauses, hardline{})
+}
+
+// braced returns a query body between braces, indented on the lines between them.
+func (p *printer) braced(body doc) doc {
+	return docs{text("{"), nest{docs{hardline{}, body}}, hardline{}, text("}")}
+}
+
+func (p *printer) node(node ast.Node) doc {
+	switch n := node.(type) {
+	case ast.Statement:
+		return p.statement(n)
+	case ast.ReadingClause:
+		return p.readingClause(n)
+	case ast.UpdatingClause:
+		return p.updatingClause(n)
+	case ast.Expr:
+		return p.expr(n, 0)
+	case ast.PatternElement:
+		return p.patternElement(n)
+	case ast.SetItem:
+		return p.setItem(n)
+	case ast.RemoveItem:
+		return p.removeItem(n)
+	case ast.CypherType:
+		return p.cypherType(n, true)
+	case ast.LabelExpr:
+		return p.labelExpr(n, 0)
+	case ast.SchemaName:
+		return p.schemaName(n)
+	case ast.SymbolicName:
+		return p.symbolicName(n)
+	case ast.MapProjectionSelector:
+		return p.mapProjectionSelector(n)
+	case ast.FunctionName:
+		return p.functionName(n)
+	case *ast.Union:
+		return p.union(n)
+	case *ast.MultiPartQueryPart:
+		return p.multiPartQueryPart(n)
+	case *ast.WithClause:
+		return p.withClause(n)
+	case *ast.Projection:
+		return docs{p.kw("RETURN "), p.projection(n)}
+	case *ast.ProjectionItems:
+		return p.projectionItems(n)
+	case *ast.ProjectionItem:
+		return p.projectionItem(n)
+	case *ast.SortOrder:
+		return p.sortOrder(n)
+	case *ast.SortItem:
+		return p.sortItem(n)
+	case *ast.ProcedureInvocation:
+		return p.procedureInvocation(n)
+	case *ast.YieldItems:
+		return p.yieldItems(n)
+	case *ast.YieldItem:
+		return p.yieldItem(n)
+	case *ast.MergeAction:
+		return p.mergeAction(n)
+	case *ast.IndexDefinition:
+		return p.indexDefinition(n)
+	case *ast.ConstraintDefinition:
+		return p.constraintDefinition(n)
+	case *ast.Pattern:
+		return p.pattern(n)
+	case *ast.PatternPart:
+		return p.patternPart(n)
+	case *ast.PathSelector:
+		return p.pathSelector(n)
+	case *ast.NodePattern:
+		return p.nodePattern(n)
+	case *ast.PatternElementChain:
+		return p.patternElementChain(n)
+	case *ast.RelationshipPattern:
+		return p.relationshipPattern(n)
+	case *ast.RelationshipDetail:
+		return p.relationshipDetail(n)
+	case *ast.RangeLiteral:
+		return p.rangeLiteral(n)
+	case *ast.Properties:
+		return p.properties(n)
+	case *ast.CaseAltNode:
+		return p.caseAlt(n)
+	case *ast.PropertyKeyName:
+		return p.propertyKeyName(n)
+	}
+	return p.unsupported(node)
+}
+
+func (p *printer) statement(stmt ast.Statement) doc {
+	switch s := stmt.(type) {
+	case *ast.UnionQuery:
+		union := docs{p.statement(s.Query)}
+		for _, u := range s.Unions {
+			union = append(union, hardline{}, p.union(u))
+		}
+		return union
+	case *ast.SinglePartQuery:
+		return p.clauses(p.singlePartQuery(s))
+	case *ast.MultiPartQuery:
+		clauses := []doc{}
+		for _, part := range s.Parts {
+			clauses = append(clauses, p.multiPartQueryPart(part))
+		}
+		return p.clauses(append(clauses, p.clauses(p.singlePartQuery(s.SinglePartQuery))))
+	case *ast.StandaloneCall:
+		call := docs{p.kw("CALL "), p.procedureInvocation(s.Procedure)}
+		if s.YieldAll {
+			return append(call, p.kw(" YIELD *"))
+		}
+		if s.YieldItems != nil {
+			call = append(call, p.kw(" YIELD "), p.yieldItems(s.YieldItems))
+		}
+		return call
+	case *ast.CreateIndex:
+		command := docs{p.kw("CREATE INDEX"), p.schemaCommandName(s.Name, s.IfNotExists, "IF NOT EXISTS")}
+		if s.Index.Variable == nil {
+			return append(command, p.kw(" ON "), p.indexDefinition(s.Index))
+		}
+		return append(command, p.kw(" FOR "), p.indexDefinition(s.Index))
+	case *ast.DropIndex:
+		if s.Index != nil {
+			return docs{p.kw("DROP INDEX ON "), p.indexDefinition(s.Index)}
+		}
+		return docs{p.kw("DROP INDEX"), p.schemaCommandName(s.Name, s.IfExists, "IF EXISTS")}
+	case *ast.CreateConstraint:
+		return docs{
+			p.kw("CREATE CONSTRAINT"),
+			p.schemaCommandName(s.Name, s.IfNotExists, "IF NOT EXISTS"),
+			p.kw(" FOR "),
+			p.constraintDefinition(s.Constraint),
+		}
+	case *ast.DropConstraint:
+		if s.Constraint != nil {
+			return docs{p.kw("DROP CONSTRAINT ON "), p.constraintDefinition(s.Constraint)}
+		}
+		return docs{p.kw("DROP CONSTRAINT"), p.schemaCommandName(s.Name, s.IfExists, "IF EXISTS")}
+	}
+	return p.unsupported(stmt)
+}
+
+func (p *printer) union(u *ast.Union) doc {
+	keyword := "UNION"
+	if u.All {
+		keyword = "UNION ALL"
+	}
+	return docs{p.kw(keyword), hardline{}, p.statement(u.Query)}
+}
+
+func (p *printer) singlePartQuery(q *ast.SinglePartQuery) []doc {
+	clauses := []doc{}
+	for _, clause := range q.ReadingClause {
+		clauses = append(clauses, p.readingClause(clause))
+	}
+	for _, clause := range q.UpdatingClause {
+		clauses = append(clauses, p.updatingClause(clause))
+	}
+	if q.Projection != nil {
+		clauses = append(clauses, docs{p.kw("RETURN "), p.projection(q.Projection)})
+	}
+	return clauses
+}
+
+func (p *printer) multiPartQueryPart(part *ast.MultiPartQueryPart) doc {
+	clauses := []doc{}
+	for _, clause := range part.ReadingClause {
+		clauses = append(clauses, p.readingClause(clause))
+	}
+	for _, clause := range part.UpdatingClause {
+		clauses = append(clauses, p.updatingClause(clause))
+	}
+	return p.clauses(append(clauses, p.withClause(part.With)))
+}
+
+func (p *printer) withClause(with *ast.WithClause) doc {
+	clause := docs{p.kw("WITH "), p.projection(with.Projection)}
+	if with.WhereExpr != nil {
+		clause = append(clause, hardline{}, p.where(with.WhereExpr))
+	}
+	return clause
+}
+
+func (p *printer) where(expr ast.Expr) doc {
+	return docs{p.kw("WHERE "), p.expr(expr, 0)}
+}
+
+func (p *printer) projection(projection *ast.Projection) doc {
+	body := docs{}
+	if projection.Distinct {
+		body = append(body, p.kw("DISTINCT "))
+	}
+	body = append(body, p.projectionItems(projection.Items))
+	if projection.Order != nil {
+		body = append(body, hardline{}, p.sortOrder(projection.Order))
+	}
+	if projection.Skip != nil {
+		body = append(body, hardline{}, p.kw("SKIP "), p.expr(projection.Skip, 0))
+	}
+	if projection.Limit != nil {
+		body = append(body, hardline{}, p.kw("LIMIT "), p.expr(projection.Limit, 0))
+	}
+	return body
+}
+
+func (p *printer) projectionItems(items *ast.ProjectionItems) doc {
+	list := []doc{}
+	if items.All {
+		list = append(list, text("*"))
+	}
+	for _, item := range items.Items {
+		list = append(list, p.projectionItem(item))
+	}
+	return p.list(list)
+}
+
+func (p *printer) projectionItem(item *ast.ProjectionItem) doc {
+	if item.Variable == nil {
+		return p.expr(item.Expr, 0)
+	}
+	return docs{p.expr(item.Expr, 0), p.kw(" AS "), p.symbolicName(item.Variable)}
+}
+
+func (p *printer) sortOrder(order *ast.SortOrder) doc {
+	items := []doc{}
+	for _, item := range order.Items {
+		items = append(items, p.sortItem(item))
+	}
+	return docs{p.kw("ORDER BY "), p.list(items)}
+}
+
+func (p *printer) sortItem(item *ast.SortItem) doc {
+	if item.Order == ast.Desc {
+		return docs{p.expr(item.Expr, 0), p.kw(" DESC")}
+	}
+	return p.expr(item.Expr, 0)
+}
+
+func (p *printer) readingClause(clause ast.ReadingClause) doc {
+	switch c := clause.(type) {
+	case *ast.MatchClause:
+		match := docs{}
+		if c.Optional {
+			match = append(match, p.kw("OPTIONAL "))
+		}
+		match = append(match, p.kw("MATCH "), p.pattern(c.Pattern))
+		if c.WhereExpr != nil {
+			match = append(match, hardline{}, p.where(c.WhereExpr))
+		}
+		return match
+	case *ast.UnwindClause:
+		return docs{p.kw("UNWIND "), p.expr(c.Expr, 0), p.kw(" AS "), p.symbolicName(c.Variable)}
+	case *ast.LoadCSVClause:
+		load := docs{p.kw("LOAD CSV ")}
+		if c.WithHeaders {
+			load = append(load, p.kw("WITH HEADERS "))
+		}
+		load = append(load, p.kw("FROM "), p.expr(c.URL, 0), p.kw(" AS "), p.symbolicName(c.Variable))
+		if c.FieldTerminator != "" {
+			load = append(load, p.kw(" FIELDTERMINATOR "), text(quote(c.FieldTerminator)))
+		}
+		return load
+	case *ast.SubqueryCall:
+		call := docs{p.kw("CALL "), p.subquery(nil, nil, c.Query)}
+		if c.InTransactions {
+			call = append(call, p.kw(" IN TRANSACTIONS"))
+			if c.BatchSize != nil {
+				call = append(call, p.kw(" OF "), p.expr(c.BatchSize, 0), p.kw(" ROWS"))
+			}
+		}
+		return call
+	case *ast.InQueryCall:
+		call := docs{p.kw("CALL "), p.procedureInvocation(c.Procedure)}
+		if c.YieldItems != nil {
+			call = append(call, p.kw(" YIELD "), p.yieldItems(c.YieldItems))
+		}
+		return call
+	}
+	return p.unsupported(clause)
+}
+
+func (p *printer) procedureInvocation(procedure *ast.ProcedureInvocation) doc {
+	name := p.functionName(procedure.ProcedureName)
+	if procedure.Implicit {
+		return name
+	}
+	return docs{name, p.bracketed("(", p.exprs(procedure.Args), ")")}
+}
+
+func (p *printer) yieldItems(items *ast.YieldItems) doc {
+	list := []doc{}
+	for _, item := range items.Items {
+		list = append(list, p.yieldItem(item))
+	}
+	if items.WhereExpr == nil {
+		return p.list(list)
+	}
+	return docs{p.list(list), hardline{}, p.where(items.WhereExpr)}
+}
+
+func (p *printer) yieldItem(item *ast.YieldItem) doc {
+	if item.Field == nil {
+		return p.symbolicName(item.Variable)
+	}
+	return docs{p.symbolicName(item.Field), p.kw(" AS "), p.symbolicName(item.Variable)}
+}
+
+func (p *printer) updatingClause(clause ast.UpdatingClause) doc {
+	switch c := clause.(type) {
+	case *ast.CreateClause:
+		return docs{p.kw("CREATE "), p.pattern(c.Pattern)}
+	case *ast.MergeClause:
+		merge := docs{p.kw("MERGE "), p.patternPart(c.PatternPart)}
+		for _, action := range c.Actions {
+			merge = append(merge, nest{docs{hardline{}, p.mergeAction(action)}})
+		}
+		return merge
+	case *ast.ForeachClause:
+		p.pipeDepth++
+		list := p.expr(c.Expr, 0)
+		p.pipeDepth--
+		clauses := []doc{}
+		for _, clause := range c.UpdatingClauses {
+			clauses = append(clauses, p.updatingClause(clause))
+		}
+		return group{docs{
+			p.kw("FOREACH ("), p.symbolicName(c.Variable), p.kw(" IN "), list, text(" |"),
+			nest{docs{space, join(clauses, space)}},
+			text(")"),
+		}}
+	case *ast.SetClause:
+		items := []doc{}
+		for _, item := range c.Items {
+			items = append(items, p.setItem(item))
+		}
+		return docs{p.kw("SET "), p.list(items)}
+	case *ast.RemoveClause:
+		items := []doc{}
+		for _, item := range c.Items {
+			items = append(items, p.removeItem(item))
+		}
+		return docs{p.kw("REMOVE "), p.list(items)}
+	case *ast.DeleteClause:
+		keyword := "DELETE "
+		if c.Detach {
+			keyword = "DETACH DELETE "
+		}
+		return docs{p.kw(keyword), p.list(p.exprs(c.Exprs))}
+	}
+	return p.unsupported(clause)
+}
+
+func (p *printer) mergeAction(action *ast.MergeAction) doc {
+	keyword := "ON MATCH "
+	if action.Type == ast.OnCreate {
+		keyword = "ON CREATE "
+	}
+	return docs{p.kw(keyword), p.updatingClause(action.Set)}
+}
+
+func (p *printer) setItem(item ast.SetItem) doc {
+	switch i := item.(type) {
+	case *ast.PropertySetItem:
+		return docs{p.expr(i.Property, 0), text(" = "), p.expr(i.Expr, 0)}
+	case *ast.VariableSetItem:
+		return docs{p.symbolicName(i.Variable), text(" = "), p.expr(i.Expr, 0)}
+	case *ast.VariableAddSetItem:
+		return docs{p.symbolicName(i.Variable), text(" += "), p.expr(i.Expr, 0)}
+	case *ast.LabelsSetItem:
+		return docs{p.symbolicName(i.Variable), p.labels(i.Labels)}
+	}
+	return p.unsupported(item)
+}
+
+func (p *printer) removeItem(item ast.RemoveItem) doc {
+	switch i := item.(type) {
+	case *ast.LabelsRemoveItem:
+		return docs{p.symbolicName(i.Variable), p.labels(i.Labels)}
+	case *ast.PropertyRemoveItem:
+		return p.expr(i.Property, 0)
+	}
+	return p.unsupported(item)
+}
+
+// schemaCommandName returns the optional name of an index or constraint, followed by the optional
+// IF [NOT] EXISTS.
+func (p *printer) schemaCommandName(name ast.SymbolicName, exists bool, keywords string) doc {
+	d := docs{}
+	if name != nil {
+		d = append(d, text(" "), p.symbolicName(name))
+	}
+	if exists {
+		d = append(d, text(" "), p.kw(keywords))
+	}
+	return d
+}
+
+// indexDefinition returns the :Label(property, ...) form of an index definition when it has no variable,
+// otherwise its pattern and properties.
+func (p *printer) indexDefinition(index *ast.IndexDefinition) doc {
+	if index.Variable == nil {
+		return docs{text(":"), p.schemaName(index.Label), p.bracketed("(", p.schemaNames(index.Properties), ")")}
+	}
+	return docs{
+		p.schemaPattern(index.Variable, index.Label, index.Relationship),
+		p.kw(" ON "),
+		p.bracketed("(", p.schemaProperties(index.Variable, index.Properties), ")"),
+	}
+}
+
+func (p *printer) constraintDefinition(constraint *ast.ConstraintDefinition) doc {
+	d := docs{p.schemaPattern(constraint.Variable, constraint.Label, constraint.Relationship), p.kw(" REQUIRE ")}
+	properties := p.schemaProperties(constraint.Variable, constraint.Properties)
+	if len(properties) == 1 {
+		d = append(d, properties[0])
+	} else {
+		d = append(d, p.bracketed("(", properties, ")"))
+	}
+	if constraint.Type == ast.UniqueConstraint {
+		return append(d, p.kw(" IS UNIQUE"))
+	}
+	return append(d, p.kw(" IS NOT NULL"))
+}
+
+// schemaPattern returns the node pattern (n:Label), or the relationship pattern ()-[r:TYPE]-(), that an index
+// or constraint applies to.
+func (p *printer) schemaPattern(variable ast.SymbolicName, label ast.SchemaName, relationship bool) doc {
+	if relationship {
+		return docs{text("()-["), p.symbolicName(variable), text(":"), p.schemaName(label), text("]-()")}
+	}
+	return docs{text("("), p.symbolicName(variable), text(":"), p.schemaName(label), text(")")}
+}
+
+func (p *printer) schemaProperties(variable ast.SymbolicName, properties []ast.SchemaName) []doc {
+	list := []doc{}
+	for _, property := range properties {
+		list = append(list, docs{p.symbolicName(variable), text("."), p.schemaName(property)})
+	}
+	return list
+}
+
+func (p *printer) schemaNames(names []ast.SchemaName) []doc {
+	list := []doc{}
+	for _, name := range names {
+		list = append(list, p.schemaName(name))
+	}
+	return list
+}
+
+func (p *printer) pattern(pattern *ast.Pattern) doc {
+	parts := []doc{}
+	for _, part := range pattern.Parts {
+		parts = append(parts, p.patternPart(part))
+	}
+	return p.list(parts)
+}
+
+func (p *printer) patternPart(part *ast.PatternPart) doc {
+	d := docs{}
+	if part.Variable != nil {
+		d = append(d, p.symbolicName(part.Variable), text(" = "))
+	}
+	if part.Selector != nil {
+		d = append(d, p.pathSelector(part.Selector), text(" "))
+	}
+	return append(d, p.patternElement(part.Element))
+}
+
+func (p *printer) pathSelector(selector *ast.PathSelector) doc {
+	switch selector.Kind {
+	case ast.AnyShortestPath:
+		return p.kw("ANY SHORTEST")
+	case ast.AllShortestPaths:
+		return p.kw("ALL SHORTEST")
+	}
+	return docs{p.kw("SHORTEST "), text(strconv.FormatInt(selector.Count, 10))}
+}
+
+func (p *printer) patternElement(element ast.PatternElement) doc {
+	switch e := element.(type) {
+	case *ast.PatternElementPattern:
+		return p.chain(e.Left, e.Chain)
+	case *ast.PatternElementNested:
+		d := docs{text("("), p.patternElement(e.Element), text(")")}
+		if e.Quantifier != nil {
+			d = append(d, p.quantifier(e.Quantifier))
+		}
+		return d
+	case *ast.PatternElementSequence:
+		elements := []doc{}
+		for _, element := range e.Elements {
+			elements = append(elements, p.patternElement(element))
+		}
+		return group{join(elements, space)}
+	case *ast.ShortestPathPattern:
+		name := "shortestPath("
+		if e.All {
+			name = "allShortestPaths("
+		}
+		return docs{text(name), p.patternElement(e.Element), text(")")}
+	}
+	return p.unsupported(element)
+}
+
+// quantifier returns the quantifier of a parenthesized path pattern: '+', '*', {n}, {m,} or {m,n}.
+func (p *printer) quantifier(quantifier *ast.RangeLiteral) doc {
+	begin := strconv.FormatInt(quantifier.Begin, 10)
+	switch {
+	case quantifier.End == math.MaxInt64 && quantifier.Begin == 0:
+		return text("*")
+	case quantifier.End == math.MaxInt64 && quantifier.Begin == 1:
+		return text("+")
+	case quantifier.End == math.MaxInt64:
+		return text("{" + begin + ",}")
+	case quantifier.Begin == quantifier.End:
+		return text("{" + begin + "}")
+	}
+	return text("{" + begin + "," + strconv.FormatInt(quantifier.End, 10) + "}")
+}
+
+func (p *printer) chain(left *ast.NodePattern, chain []*ast.PatternElementChain) doc {
+	d := docs{p.nodePattern(left)}
+	for _, c := range chain {
+		d = append(d, p.patternElementChain(c))
+	}
+	return d
+}
+
+func (p *printer) patternElementChain(chain *ast.PatternElementChain) doc {
+	return docs{p.relationshipPattern(chain.RelationshipPattern), p.nodePattern(chain.Right)}
+}
+
+func (p *printer) nodePattern(node *ast.NodePattern) doc {
+	parts := []doc{}
+	if node.Variable != nil || len(node.Labels) > 0 || node.LabelExpr != nil {
+		parts = append(parts, docs{p.optionalSymbolicName(node.Variable), p.labels(node.Labels), p.labelExprLabels(node.LabelExpr)})
+	}
+	if node.Properties != nil {
+		parts = append(parts, p.properties(node.Properties))
+	}
+	if node.WhereExpr != nil {
+		parts = append(parts, p.patternPredicate(node.WhereExpr))
+	}
+	return docs{text("("), join(parts, text(" ")), text(")")}
+}
+
+func (p *printer) relationshipPattern(pattern *ast.RelationshipPattern) doc {
+	d := docs{}
+	if pattern.Left == ast.Directed {
+		d = append(d, text("<"))
+	}
+	d = append(d, text("-"))
+	if pattern.RelationshipDetail != nil {
+		d = append(d, p.relationshipDetail(pattern.RelationshipDetail))
+	}
+	d = append(d, text("-"))
+	if pattern.Right == ast.Directed {
+		d = append(d, text(">"))
+	}
+	return d
+}
+
+func (p *printer) relationshipDetail(detail *ast.RelationshipDetail) doc {
+	parts := []doc{}
+	if detail.Variable != nil || len(detail.RelationshipTypes) > 0 || detail.LabelExpr != nil || detail.RangeLiteral != nil {
+		d := docs{p.optionalSymbolicName(detail.Variable)}
+		if len(detail.RelationshipTypes) > 0 {
+			d = append(d, text(":"), p.relationshipTypes(detail.RelationshipTypes))
+		}
+		d = append(d, p.labelExprLabels(detail.LabelExpr))
+		if detail.RangeLiteral != nil {
+			d = append(d, p.rangeLiteral(detail.RangeLiteral))
+		}
+		parts = append(parts, d)
+	}
+	if detail.Properties != nil {
+		parts = append(parts, p.properties(detail.Properties))
+	}
+	if detail.WhereExpr != nil {
+		parts = append(parts, p.patternPredicate(detail.WhereExpr))
+	}
+	return docs{text("["), join(parts, text(" ")), text("]")}
+}
+
+// relationshipTypes returns the disjunction of types A|B, which is enclosed in brackets where a top-level '|'
+// would end an expression.
+func (p *printer) relationshipTypes(types []ast.SchemaName) doc {
+	d := join(p.schemaNames(types), text("|"))
+	if len(types) > 1 && p.pipeDepth > 0 {
+		return docs{text("("), d, text(")")}
+	}
+	return d
+}
+
+func (p *printer) rangeLiteral(literal *ast.RangeLiteral) doc {
+	d := docs{text("*")}
+	if literal.Begin != math.MinInt64 {
+		d = append(d, text(strconv.FormatInt(literal.Begin, 10)))
+	}
+	if literal.End != math.MaxInt64 {
+		d = append(d, text(".."+strconv.FormatInt(literal.End, 10)))
+	}
+	return d
+}
+
+func (p *printer) properties(properties *ast.Properties) doc {
+	if properties.MapLiteral != nil {
+		return p.expr(properties.MapLiteral, 0)
+	}
+	return p.expr(properties.Parameter, 0)
+}
+
+// patternPredicate returns the inline WHERE of a node or relationship pattern. The pattern's brackets delimit
+// it, so a '|' within it never ends an enclosing expression.
+func (p *printer) patternPredicate(expr ast.Expr) doc {
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	defer func() { p.pipeDepth = pipeDepth }()
+	return p.where(expr)
+}
+
+func (p *printer) labels(labels []ast.SchemaName) doc {
+	d := docs{}
+	for _, label := range labels {
+		d = append(d, text(":"), p.schemaName(label))
+	}
+	return d
+}
+
+// labelExprLabels returns the ':' and label expression of a node pattern or label predicate, if it has one.
+func (p *printer) labelExprLabels(expr ast.LabelExpr) doc {
+	if expr == nil {
+		return nil
+	}
+	if _, ok := expr.(*ast.LabelOr); ok && p.pipeDepth > 0 {
+		return docs{text(":"), p.labelExpr(expr, labelPrecPrimary)}
+	}
+	return docs{text(":"), p.labelExpr(expr, 0)}
+}
+
+// labelExpr returns expr, enclosed in brackets when its precedence is lower than min.
+func (p *printer) labelExpr(expr ast.LabelExpr, min int) doc {
+	var d doc
+	prec := labelPrecPrimary
+	switch e := expr.(type) {
+	case *ast.LabelName:
+		d = p.schemaName(e.Name)
+	case *ast.LabelWildcard:
+		d = text("%")
+	case *ast.LabelNot:
+		prec = labelPrecNot
+		d = docs{text("!"), p.labelExpr(e.Expr, labelPrecNot)}
+	case *ast.LabelAnd:
+		prec = labelPrecAnd
+		d = docs{p.labelExpr(e.Left, labelPrecAnd), text("&"), p.labelExpr(e.Right, labelPrecAnd+1)}
+	case *ast.LabelOr:
+		prec = labelPrecOr
+		d = docs{p.labelExpr(e.Left, labelPrecOr), text("|"), p.labelExpr(e.Right, labelPrecOr+1)}
+	default:
+		return p.unsupported(expr)
+	}
+	if prec >= min {
+		return d
+	}
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	defer func() { p.pipeDepth = pipeDepth }()
+	return docs{text("("), p.labelExpr(expr, 0), text(")")}
+}
+
+func (p *printer) schemaName(name ast.SchemaName) doc {
+	switch n := name.(type) {
+	case *ast.SymbolicNameSchemaName:
+		return p.symbolicName(n.SymbolicName)
+	case *ast.ReservedWordSchemaName:
+		if word, ok := scanner.ReservedWord(n.TokenType); ok {
+			return p.kw(word)
+		}
+	}
+	return p.unsupported(name)
+}
+
+func (p *printer) symbolicName(name ast.SymbolicName) doc {
+	switch n := name.(type) {
+	case *ast.SymbolicNameIdentifier:
+		return text(n.Identifier.Lexeme)
+	case *ast.SymbolicNameHexLetter:
+		return text(string(n.Letter))
+	}
+	return p.unsupported(name)
+}
+
+func (p *printer) optionalSymbolicName(name ast.SymbolicName) doc {
+	if name == nil {
+		return nil
+	}
+	return p.symbolicName(name)
+}
+
+func (p *printer) functionName(name ast.FunctionName) doc {
+	switch n := name.(type) {
+	case *ast.SymbolicFunctionName:
+		d := docs{}
+		for _, namespace := range n.Namespace {
+			d = append(d, p.symbolicName(namespace), text("."))
+		}
+		return append(d, p.symbolicName(n.FunctionName))
+	case *ast.ExistsFunctionName:
+		return p.kw("EXISTS")
+	}
+	return p.unsupported(name)
+}
+
+func (p *printer) exprs(exprs []ast.Expr) []doc {
+	list := []doc{}
+	for _, expr := range exprs {
+		list = append(list, p.expr(expr, 0))
+	}
+	return list
+}
+
+// precedence returns the precedence of expr, which is that of the parser production it is parsed by.
+func precedence(expr ast.Expr) int {
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case ast.Or:
+			return precOr
+		case ast.Xor:
+			return precXor
+		case ast.And:
+			return precAnd
+		case ast.Add, ast.Subtract:
+			return precAdd
+		case ast.Multiply, ast.Divide, ast.Modulo:
+			return precMultiply
+		case ast.PowerOf:
+			return precPower
+		case ast.StringOrListOp:
+			return precStringOrList
+		}
+		return precComparison
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case ast.Not:
+			return precNot
+		case ast.Negate:
+			return precUnary
+		}
+		return precStringOrList
+	case *ast.PropertyLabelsExpr:
+		return precPropertyLabels
+	case *ast.ListExpr, *ast.ListOperatorExpr, *ast.TypePredicateExpr:
+		return precStringOrList
+	case *ast.OpExpr:
+		if e.Op != ast.CountAll {
+			return precStringOrList
+		}
+	}
+	return precAtom
+}
+
+// expr returns expr, enclosed in parentheses when its precedence is lower than min.
+func (p *printer) expr(expr ast.Expr, min int) doc {
+	if precedence(expr) < min {
+		return docs{text("("), p.expr(expr, 0), text(")")}
+	}
+	switch e := expr.(type) {
+	case *ast.BinaryExpr:
+		if e.Op == ast.StringOrListOp {
+			return p.stringOrListExpr(e)
+		}
+		return p.binaryExpr(e)
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case ast.Not:
+			return docs{p.kw("NOT "), p.expr(e.Expr, precComparison)}
+		case ast.Negate:
+			return docs{text("-"), p.expr(e.Expr, precStringOrList)}
+		}
+		return p.postfix(e)
+	case *ast.ListExpr:
+		d := docs{}
+		for i, op := range e.List {
+			if i > 0 {
+				d = append(d, p.postfixSeparator(op))
+			}
+			d = append(d, p.postfix(op))
+		}
+		return d
+	case *ast.ListOperatorExpr, *ast.TypePredicateExpr:
+		return p.postfix(e)
+	case *ast.OpExpr:
+		if e.Op == ast.CountAll {
+			return text("count(*)")
+		}
+		return p.postfix(e)
+	case *ast.TernaryExpr:
+		if e.Op == ast.ListRange {
+			return docs{p.expr(e.E1, precPropertyLabels), text("["), p.expr(e.E2, 0), text(".."), p.expr(e.E3, 0), text("]")}
+		}
+	case *ast.PropertyLabelsExpr:
+		d := docs{p.expr(e.Atom, precAtom)}
+		for _, key := range e.PropertyKeys {
+			d = append(d, text("."), p.schemaName(key))
+		}
+		return append(d, p.labels(e.Labels), p.labelExprLabels(e.LabelExpr))
+	case *ast.VariableExpr:
+		return p.symbolicName(e.SymbolicName)
+	case *ast.PrimitiveLiteral:
+		return p.primitiveLiteral(e)
+	case *ast.ListLiteral:
+		return p.bracketed("[", p.exprs(e.Items), "]")
+	case *ast.MapLiteral:
+		entries := []doc{}
+		for _, entry := range e.PropertyKeyNames {
+			entries = append(entries, p.propertyKeyName(entry))
+		}
+		return p.bracketed("{", entries, "}")
+	case *ast.Parameter:
+		if e.SymbolicName != nil {
+			return docs{text("$"), p.symbolicName(e.SymbolicName)}
+		}
+		return text("$" + e.N.Lexeme)
+	case *ast.CaseExpr:
+		d := docs{p.kw("CASE")}
+		if e.Init != nil {
+			d = append(d, text(" "), p.expr(e.Init, 0))
+		}
+		alternatives := docs{}
+		for _, alternative := range e.Alternatives {
+			alternatives = append(alternatives, space, p.caseAlt(alternative))
+		}
+		if e.Else != nil {
+			alternatives = append(alternatives, space, p.kw("ELSE "), p.expr(e.Else, 0))
+		}
+		return group{append(d, nest{alternatives}, space, p.kw("END"))}
+	case *ast.ListComprehensionExpr:
+		p.pipeDepth++
+		filter := p.expr(e.FilterExpr, 0)
+		p.pipeDepth--
+		d := docs{text("["), filter}
+		if e.Expr != nil {
+			d = append(d, text(" | "), p.expr(e.Expr, 0))
+		}
+		return append(d, text("]"))
+	case *ast.FilterExpr:
+		d := docs{p.symbolicName(e.Variable), p.kw(" IN "), p.expr(e.InExpr, 0)}
+		if e.WhereExpr != nil {
+			d = append(d, text(" "), p.where(e.WhereExpr))
+		}
+		return d
+	case *ast.QuantifierExpr:
+		if name, ok := quantifiers[e.Op]; ok {
+			return docs{p.kw(name), text("("), p.expr(e.Expr, 0), text(")")}
+		}
+	case *ast.PatternComprehensionExpr:
+		d := docs{text("[")}
+		if e.Variable != nil {
+			d = append(d, p.symbolicName(e.Variable), text(" = "))
+		}
+		d = append(d, p.expr(e.ReltionshipsPattern, 0))
+		if e.WhereExpr != nil {
+			p.pipeDepth++
+			d = append(d, text(" "), p.where(e.WhereExpr))
+			p.pipeDepth--
+		}
+		return append(d, text(" | "), p.expr(e.PipeExpr, 0), text("]"))
+	case *ast.RelationshipsPattern:
+		return p.chain(e.Left, e.Chain)
+	case *ast.ShortestPathPattern:
+		return p.patternElement(e)
+	case *ast.FunctionInvocation:
+		args := p.exprs(e.Args)
+		if e.Distinct {
+			if len(args) == 0 {
+				return docs{p.functionName(e.FunctionName), text("("), p.kw("DISTINCT"), text(")")}
+			}
+			args[0] = docs{p.kw("DISTINCT "), args[0]}
+		}
+		return docs{p.functionName(e.FunctionName), p.bracketed("(", args, ")")}
+	case *ast.ExistsSubqueryExpr:
+		return docs{p.kw("EXISTS "), p.subquery(e.Pattern, e.WhereExpr, e.Query)}
+	case *ast.CountSubqueryExpr:
+		return docs{p.kw("COUNT "), p.subquery(e.Pattern, e.WhereExpr, e.Query)}
+	case *ast.MapProjectionExpr:
+		selectors := []doc{}
+		for _, selector := range e.Selectors {
+			selectors = append(selectors, p.mapProjectionSelector(selector))
+		}
+		return docs{p.symbolicName(e.Variable), text(" "), p.bracketed("{", selectors, "}")}
+	}
+	return p.unsupported(expr)
+}
+
+// binaryExpr returns a chain of operators with the same precedence, such as a + b - c, continuing on indented
+// lines when it does not fit on one.
+func (p *printer) binaryExpr(expr *ast.BinaryExpr) doc {
+	prec := precedence(expr)
+	ops := []*ast.BinaryExpr{}
+	var left ast.Expr = expr
+	for {
+		e, ok := left.(*ast.BinaryExpr)
+		if !ok || precedence(e) != prec {
+			break
+		}
+		ops = append(ops, e)
+		left = e.Left
+	}
+	rest := docs{}
+	for i := len(ops) - 1; i >= 0; i-- {
+		op, ok := operators[ops[i].Op]
+		if !ok {
+			return p.unsupported(ops[i])
+		}
+		rest = append(rest, space, p.kw(op+" "), p.expr(ops[i].Right, prec+1))
+	}
+	return group{docs{p.expr(left, prec), nest{rest}}}
+}
+
+// stringOrListExpr returns an expression followed by its string, list, null and type predicate operators.
+func (p *printer) stringOrListExpr(expr *ast.BinaryExpr) doc {
+	d := docs{p.expr(expr.Left, precPropertyLabels)}
+	list, ok := expr.Right.(*ast.ListExpr)
+	if !ok {
+		return p.unsupported(expr)
+	}
+	for _, op := range list.List {
+		d = append(d, p.postfixSeparator(op), p.postfix(op))
+	}
+	return d
+}
+
+// postfixSeparator returns the space preceding an operator following an expression, which is left out before
+// a list index or slice.
+func (p *printer) postfixSeparator(op ast.Expr) doc {
+	if e, ok := op.(*ast.ListOperatorExpr); ok && e.Op != ast.InList {
+		return nil
+	}
+	return text(" ")
+}
+
+// postfix returns an operator that follows the expression it applies to, such as IS NULL or [0].
+func (p *printer) postfix(op ast.Expr) doc {
+	switch e := op.(type) {
+	case *ast.UnaryExpr:
+		switch e.Op {
+		case ast.StartsWith:
+			return docs{p.kw("STARTS WITH "), p.expr(e.Expr, precPropertyLabels)}
+		case ast.EndsWith:
+			return docs{p.kw("ENDS WITH "), p.expr(e.Expr, precPropertyLabels)}
+		case ast.Contains:
+			return docs{p.kw("CONTAINS "), p.expr(e.Expr, precPropertyLabels)}
+		}
+	case *ast.ListOperatorExpr:
+		switch e.Op {
+		case ast.InList:
+			return docs{p.kw("IN "), p.expr(e.Expr, precPropertyLabels)}
+		case ast.ListIndex:
+			return docs{text("["), p.expr(e.Expr, 0), text("]")}
+		case ast.ListRange:
+			d := docs{text("[")}
+			if e.Expr != nil {
+				d = append(d, p.expr(e.Expr, 0))
+			}
+			d = append(d, text(".."))
+			if e.EndExpr != nil {
+				d = append(d, p.expr(e.EndExpr, 0))
+			}
+			return append(d, text("]"))
+		}
+	case *ast.OpExpr:
+		switch e.Op {
+		case ast.IsNull:
+			return p.kw("IS NULL")
+		case ast.IsNotNull:
+			return p.kw("IS NOT NULL")
+		}
+	case *ast.TypePredicateExpr:
+		if e.Not {
+			return docs{p.kw("IS NOT :: "), p.cypherType(e.Type, true)}
+		}
+		return docs{p.kw("IS :: "), p.cypherType(e.Type, true)}
+	}
+	return p.unsupported(op)
+}
+
+func (p *printer) caseAlt(alternative *ast.CaseAltNode) doc {
+	return docs{p.kw("WHEN "), p.expr(alternative.When, 0), p.kw(" THEN "), p.expr(alternative.Then, 0)}
+}
+
+func (p *printer) propertyKeyName(entry *ast.PropertyKeyName) doc {
+	return docs{p.schemaName(entry.Name), text(": "), p.expr(entry.Expr, 0)}
+}
+
+func (p *printer) mapProjectionSelector(selector ast.MapProjectionSelector) doc {
+	switch s := selector.(type) {
+	case *ast.PropertySelector:
+		return docs{text("."), p.schemaName(s.Property)}
+	case *ast.AllPropertiesSelector:
+		return text(".*")
+	case *ast.LiteralEntrySelector:
+		return docs{p.schemaName(s.Key), text(": "), p.expr(s.Expr, 0)}
+	case *ast.VariableSelector:
+		return p.symbolicName(s.Variable)
+	}
+	return p.unsupported(selector)
+}
+
+// subquery returns the braced body of a subquery expression or call, which is either a pattern with an
+// optional WHERE expression, or a query. The braces delimit the body, so a '|' within it never ends an
+// enclosing expression.
+func (p *printer) subquery(pattern *ast.Pattern, where ast.Expr, query ast.Query) doc {
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	defer func() { p.pipeDepth = pipeDepth }()
+	if query != nil {
+		return p.braced(p.statement(query))
+	}
+	body := docs{p.pattern(pattern)}
+	if where != nil {
+		body = append(body, space, p.where(where))
+	}
+	return group{docs{text("{"), nest{docs{space, body}}, space, text("}")}}
+}
+
+func (p *printer) primitiveLiteral(literal *ast.PrimitiveLiteral) doc {
+	switch literal.Kind {
+	case scanner.Integer:
+		if n, ok := literal.Value.(int64); ok {
+			return text(strconv.FormatInt(n, 10))
+		}
+	case scanner.Double:
+		if f, ok := literal.Value.(float64); ok {
+			return text(formatDouble(f))
+		}
+	case scanner.String:
+		if s, ok := literal.Value.(string); ok {
+			return text(quote(s))
+		}
+	case scanner.True:
+		return p.kw("TRUE")
+	case scanner.False:
+		return p.kw("FALSE")
+	case scanner.Null:
+		return p.kw("NULL")
+	}
+	return p.unsupported(literal)
+}
+
+// formatDouble returns f in a form the scanner reads as a double: with a fraction, or with an exponent when
+// it is very large or small.
+func formatDouble(f float64) string {
+	abs := math.Abs(f)
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		return strings.Replace(strconv.FormatFloat(f, 'E', -1, 64), "+", "", 1)
+	}
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+var escapes = map[rune]string{
+	'\'': `\'`,
+	'\\': `\\`,
+	'\b': `\b`,
+	'\f': `\f`,
+	'\n': `\n`,
+	'\r': `\r`,
+	'\t': `\t`,
+}
+
+// quote returns s as a single quoted string literal.
+func quote(s string) string {
+	b := strings.Builder{}
+	b.WriteByte('\'')
+	for _, ch := range s {
+		if escape, ok := escapes[ch]; ok {
+			b.WriteString(escape)
+		} else {
+			b.WriteRune(ch)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// cypherType returns a type. A union of types is written A | B when top is true and a '|' would not end an
+// enclosing expression, and ANY<A | B> otherwise.
+func (p *printer) cypherType(t ast.CypherType, top bool) doc {
+	var d doc
+	notNull := false
+	switch t := t.(type) {
+	case *ast.SimpleType:
+		name, ok := typeNames[t.Type]
+		if !ok {
+			return p.unsupported(t)
+		}
+		d, notNull = p.kw(name), t.NotNull
+	case *ast.ListType:
+		if union, ok := t.Element.(*ast.UnionType); ok && !union.NotNull {
+			d = docs{p.kw("LIST"), p.nestedTypes(union.Types)}
+		} else {
+			d = docs{p.kw("LIST"), p.nestedTypes([]ast.CypherType{t.Element})}
+		}
+		notNull = t.NotNull
+	case *ast.UnionType:
+		if top && !t.NotNull && len(t.Types) > 1 && p.pipeDepth == 0 {
+			return p.unionTypes(t.Types)
+		}
+		d, notNull = docs{p.kw("ANY"), p.nestedTypes(t.Types)}, t.NotNull
+	default:
+		return p.unsupported(t)
+	}
+	if notNull {
+		return docs{d, p.kw(" NOT NULL")}
+	}
+	return d
+}
+
+// nestedTypes returns the <A | B> following LIST or ANY. The angle brackets delimit the types, so a '|'
+// within them is always a union.
+func (p *printer) nestedTypes(types []ast.CypherType) doc {
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	defer func() { p.pipeDepth = pipeDepth }()
+	return docs{text("<"), p.unionTypes(types), text(">")}
+}
+
+func (p *printer) unionTypes(types []ast.CypherType) doc {
+	members := []doc{}
+	for _, member := range types {
+		members = append(members, p.cypherType(member, false))
+	}
+	return join(members, text(" | "))
+}
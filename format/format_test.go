@@ -0,0 +1,220 @@
+package format
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/parser"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/mburbidg/cypher/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goast "go/ast"
+	goparser "go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type testReporter struct{}
+
+func (r testReporter) Error(line int, msg string) error {
+	return utils.ParseError{Line: line, Msg: msg}
+}
+
+func parse(src string) (ast.Statement, error) {
+	reporter := testReporter{}
+	p := parser.New(scanner.New([]byte(src), reporter), reporter)
+	stmt, err := p.Parse()
+	return stmt.AST, err
+}
+
+var (
+	nodeSpanType = reflect.TypeOf(ast.NodeSpan{})
+	tokenType    = reflect.TypeOf(scanner.Token{})
+)
+
+// clearPositions zeroes the spans of node and everything below it, and the positions of their tokens, so
+// trees parsed from different text can be compared.
+func clearPositions(node any) {
+	clearValuePositions(reflect.ValueOf(node))
+}
+
+func clearValuePositions(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			clearValuePositions(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearValuePositions(v.Index(i))
+		}
+	case reflect.Struct:
+		if v.Type() == tokenType && v.CanSet() {
+			v.FieldByName("Line").SetInt(0)
+			v.FieldByName("Span").Set(reflect.Zero(v.FieldByName("Span").Type()))
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if field.Type() == nodeSpanType {
+				field.Set(reflect.Zero(nodeSpanType))
+			} else if field.CanSet() {
+				clearValuePositions(field)
+			}
+		}
+	}
+}
+
+// corpus returns the Cypher in the parser tests: the string literals starting test cases, and those passed
+// as []byte to the scanner.
+func corpus(t *testing.T) []string {
+	files, err := filepath.Glob("../parser/*_test.go")
+	require.NoError(t, err)
+	srcs := []string{}
+	add := func(expr goast.Expr) {
+		if lit, ok := expr.(*goast.BasicLit); ok && lit.Kind == token.STRING {
+			if src, err := strconv.Unquote(lit.Value); err == nil {
+				srcs = append(srcs, src)
+			}
+		}
+	}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := goparser.ParseFile(fset, file, nil, 0)
+		require.NoError(t, err)
+		goast.Inspect(f, func(n goast.Node) bool {
+			switch n := n.(type) {
+			case *goast.CompositeLit:
+				if len(n.Elts) > 0 {
+					add(n.Elts[0])
+				}
+			case *goast.CallExpr:
+				if array, ok := n.Fun.(*goast.ArrayType); ok && len(n.Args) == 1 {
+					if ident, ok := array.Elt.(*goast.Ident); ok && ident.Name == "byte" {
+						add(n.Args[0])
+					}
+				}
+			}
+			return true
+		})
+	}
+	return srcs
+}
+
+func TestRoundTrip(t *testing.T) {
+	trees := map[string]ast.Statement{}
+	for _, src := range corpus(t) {
+		if tree, err := parse(src); err == nil {
+			trees[src] = tree
+		} else if tree, err := parse("RETURN " + src); err == nil {
+			trees[src] = tree
+		}
+	}
+	require.Greater(t, len(trees), 500)
+
+	options := map[string]Options{
+		"default":   DefaultOptions,
+		"lowercase": {KeywordCase: LowerCase, Indent: "  ", LineWidth: 80},
+		"narrow":    {KeywordCase: UpperCase, Indent: "\t", LineWidth: 10},
+		"unlimited": {KeywordCase: UpperCase, Indent: "    "},
+	}
+	for name, opts := range options {
+		t.Run(name, func(t *testing.T) {
+			for src, tree := range trees {
+				formatted, err := Format(tree, opts)
+				if !assert.NoError(t, err, src) {
+					continue
+				}
+				reparsed, err := parse(formatted)
+				if !assert.NoError(t, err, "%s\nformatted as\n%s", src, formatted) {
+					continue
+				}
+				clearPositions(tree)
+				clearPositions(reparsed)
+				assert.Equal(t, tree, reparsed, "%s\nformatted as\n%s", src, formatted)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := map[string]struct {
+		src       string
+		options   Options
+		formatted string
+	}{
+		"clauses": {
+			"match (n:Person)-[r:KNOWS]->(m) where n.age>30 return m.name as name order by name desc limit 10",
+			DefaultOptions,
+			"MATCH (n:Person)-[r:KNOWS]->(m)\nWHERE n.age > 30\nRETURN m.name AS name\nORDER BY name DESC\nLIMIT 10",
+		},
+		"lowercase": {
+			"MATCH (n) RETURN n IS NOT NULL",
+			Options{KeywordCase: LowerCase, Indent: "  ", LineWidth: 80},
+			"match (n)\nreturn n is not null",
+		},
+		"precedence": {
+			"RETURN 1+2*3, (1+2)*3, a OR b AND c, -x^2, NOT a=b",
+			DefaultOptions,
+			"RETURN 1 + 2 * 3, (1 + 2) * 3, a OR b AND c, -x ^ 2, NOT a = b",
+		},
+		"subquery": {
+			"CALL { MATCH (n) RETURN n } RETURN n",
+			DefaultOptions,
+			"CALL {\n  MATCH (n)\n  RETURN n\n}\nRETURN n",
+		},
+		"line width": {
+			"RETURN [alpha, beta, gamma, delta]",
+			Options{KeywordCase: UpperCase, Indent: "  ", LineWidth: 20},
+			"RETURN [\n  alpha,\n  beta,\n  gamma,\n  delta\n]",
+		},
+		"literals": {
+			"RETURN 'it\\'s', 1.5, 0.0, 1E-7, true, null, $p",
+			DefaultOptions,
+			"RETURN 'it\\'s', 1.5, 0.0, 1E-07, TRUE, NULL, $p",
+		},
+		"label union in comprehension": {
+			"RETURN [x IN l WHERE x:(A|B) | x]",
+			DefaultOptions,
+			"RETURN [x IN l WHERE x:(A|B) | x]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tree, err := parse(tc.src)
+			require.NoError(t, err)
+			formatted, err := Format(tree, tc.options)
+			require.NoError(t, err)
+			assert.Equal(t, tc.formatted, formatted)
+		})
+	}
+}
+
+func TestFormatParenthesizes(t *testing.T) {
+	variable := func(name string) ast.Expr {
+		return &ast.VariableExpr{SymbolicName: &ast.SymbolicNameIdentifier{Identifier: scanner.Token{T: scanner.Identifier, Lexeme: name}}}
+	}
+	tests := map[string]struct {
+		expr      ast.Expr
+		formatted string
+	}{
+		"left":  {&ast.BinaryExpr{Op: ast.Multiply, Left: &ast.BinaryExpr{Op: ast.Add, Left: variable("a"), Right: variable("b")}, Right: variable("c")}, "(a + b) * c"},
+		"right": {&ast.BinaryExpr{Op: ast.Subtract, Left: variable("a"), Right: &ast.BinaryExpr{Op: ast.Subtract, Left: variable("b"), Right: variable("c")}}, "a - (b - c)"},
+		"not":   {&ast.UnaryExpr{Op: ast.Not, Expr: &ast.BinaryExpr{Op: ast.And, Left: variable("a"), Right: variable("b")}}, "NOT (a AND b)"},
+		"chain": {&ast.BinaryExpr{Op: ast.Subtract, Left: &ast.BinaryExpr{Op: ast.Add, Left: variable("a"), Right: variable("b")}, Right: variable("c")}, "a + b - c"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			formatted, err := Format(tc.expr, DefaultOptions)
+			require.NoError(t, err)
+			assert.Equal(t, tc.formatted, formatted)
+		})
+	}
+}
+
+func TestFormatUnsupported(t *testing.T) {
+	_, err := Format(&ast.TernaryExpr{Op: ast.ListIndex}, DefaultOptions)
+	assert.Error(t, err)
+}
@@ -3,13 +3,13 @@ module github.com/mburbidg/cypher
 go 1.18
 
 require (
+	github.com/cucumber/godog v0.12.6
 	github.com/smasher164/xid v0.1.1
 	github.com/stretchr/testify v1.8.0
 )
 
 require (
 	github.com/cucumber/gherkin-go/v19 v19.0.3 // indirect
-	github.com/cucumber/godog v0.12.6 // indirect
 	github.com/cucumber/messages-go/v16 v16.0.1 // indirect
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/gofrs/uuid v4.2.0+incompatible // indirect
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/cst"
 	"github.com/mburbidg/cypher/scanner"
 	"github.com/mburbidg/cypher/utils"
 	"math"
@@ -11,11 +12,18 @@ import (
 type Parser struct {
 	scanner  *scanner.Scanner
 	reporter utils.Reporter
+
+	// The depth of subqueries being parsed. Within a subquery a query does not require a RETURN.
+	subqueryDepth int
+
+	// The depth of expressions being parsed that are terminated by '|', such as the WHERE of a list
+	// comprehension. Within them a top-level '|' ends the expression rather than a label disjunction.
+	pipeDepth int
 }
 
 type Statement struct {
-	// The abstract syntax tree (AST) for the parser cypher query.
-	AST ast.Query
+	// The abstract syntax tree (AST) for the parsed cypher query or schema command.
+	AST ast.Statement
 
 	// The cypher query.
 	Cypher string
@@ -29,7 +37,7 @@ func New(scanner *scanner.Scanner, reporter utils.Reporter) *Parser {
 }
 
 func (p *Parser) Parse() (Statement, error) {
-	tree, err := p.singlePartQuery()
+	tree, err := p.statement()
 	if err != nil {
 		return Statement{}, err
 	}
@@ -39,6 +47,16 @@ func (p *Parser) Parse() (Statement, error) {
 	}, nil
 }
 
+// ParseCST parses the statement and returns its concrete syntax tree, which reproduces the source, comments
+// and whitespace included, byte for byte.
+func (p *Parser) ParseCST() (*cst.Node, error) {
+	stmt, err := p.Parse()
+	if err != nil {
+		return nil, err
+	}
+	return cst.Build(stmt.AST, scanner.NewLossless([]byte(stmt.Cypher), p.reporter)), nil
+}
+
 func (p *Parser) match(tokenTypes ...scanner.TokenType) (scanner.Token, bool, error) {
 	pos := p.scanner.Position
 	token := p.scanner.NextToken()
@@ -47,6 +65,8 @@ func (p *Parser) match(tokenTypes ...scanner.TokenType) (scanner.Token, bool, er
 		case scanner.Illegal:
 			return scanner.Token{}, false, p.reporter.Error(token.Line, "illegal character")
 		case scanner.EndOfInput:
+			// EndOfInput is not consumed, so the spans of nodes ending the input do not take in trailing trivia.
+			p.scanner.Position = pos
 			return token, false, nil
 		case tokenType:
 			return token, true, nil
@@ -82,54 +102,309 @@ func (p *Parser) matchPhrase(tokenTypes ...scanner.TokenType) ([]scanner.Token,
 	}
 }
 
-func (p *Parser) singlePartQuery() (ast.Query, error) {
-	reading := []ast.ReadingClause{}
-	updating := []ast.UpdatingClause{}
+// matchKeyword matches an identifier whose lexeme is the given keyword, ignoring case. It is used for
+// keywords, such as CALL and YIELD, that the scanner does not reserve.
+func (p *Parser) matchKeyword(keyword string) (scanner.Token, bool, error) {
+	pos := p.scanner.Position
+	if t, ok, err := p.match(scanner.Identifier); err != nil {
+		return scanner.Token{}, false, err
+	} else if ok && strings.ToUpper(t.Lexeme) == keyword {
+		return t, true, nil
+	}
+	p.scanner.Position = pos
+	return scanner.Token{}, false, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *Parser) peek() scanner.Token {
+	pos := p.scanner.Position
+	t := p.scanner.NextToken()
+	p.scanner.Position = pos
+	return t
+}
+
+// atEndOfInput reports whether all tokens have been consumed, without consuming any.
+func (p *Parser) atEndOfInput() bool {
+	return p.peek().T == scanner.EndOfInput
+}
+
+// spanned is implemented by every AST node, through its embedded ast.NodeSpan.
+type spanned interface {
+	ast.Node
+	SetSpan(span ast.Span)
+}
+
+// start returns the start of the next token, which is where the node about to be parsed begins.
+func (p *Parser) start() scanner.Pos {
+	return p.peek().Span.Start
+}
+
+// span sets the span of node to run from start to the end of the last token consumed, and returns node.
+func span[T spanned](p *Parser, start scanner.Pos, node T) T {
+	node.SetSpan(ast.Span{Start: start, End: p.scanner.Pos()})
+	return node
+}
+
+func (p *Parser) statement() (ast.Statement, error) {
+	if command, err := p.schemaCommand(); err != nil {
+		return nil, err
+	} else if command != nil {
+		return command, nil
+	}
+	return p.query()
+}
+
+func (p *Parser) query() (ast.Query, error) {
+	// A standalone call must be the whole query, otherwise the CALL is an in-query call that starts a
+	// regular query.
+	pos := p.scanner.Position
+	if call, err := p.standaloneCall(); err != nil {
+		return nil, err
+	} else if call != nil && p.atEndOfInput() {
+		return call, nil
+	}
+	p.scanner.Position = pos
+	return p.regularQuery()
+}
+
+func (p *Parser) standaloneCall() (*ast.StandaloneCall, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("CALL"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	// CALL { ... } is a subquery, which is parsed as a reading clause.
+	if p.peek().T == scanner.OpenBrace {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	procedure, err := p.procedureInvocation(true)
+	if err != nil {
+		return nil, err
+	}
+	call := &ast.StandaloneCall{Procedure: procedure}
+	if _, ok, err := p.matchKeyword("YIELD"); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, call), nil
+	}
+	if _, ok, err := p.match(scanner.Star); err != nil {
+		return nil, err
+	} else if ok {
+		call.YieldAll = true
+		return span(p, start, call), nil
+	}
+	if call.YieldItems, err = p.yieldItems(); err != nil {
+		return nil, err
+	}
+	return span(p, start, call), nil
+}
+
+func (p *Parser) regularQuery() (ast.Query, error) {
+	start := p.start()
+	query, err := p.singleQuery()
+	if err != nil {
+		return nil, err
+	}
+	unions := []*ast.Union{}
+	for {
+		unionStart := p.start()
+		if _, ok, err := p.match(scanner.Union); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		_, all, err := p.match(scanner.All)
+		if err != nil {
+			return nil, err
+		}
+		unionQuery, err := p.singleQuery()
+		if err != nil {
+			return nil, err
+		}
+		unions = append(unions, span(p, unionStart, &ast.Union{All: all, Query: unionQuery}))
+	}
+	if len(unions) == 0 {
+		return query, nil
+	}
+	return span(p, start, &ast.UnionQuery{Query: query, Unions: unions}), nil
+}
+
+func (p *Parser) singleQuery() (ast.Query, error) {
+	start := p.start()
+	parts := []*ast.MultiPartQueryPart{}
+	for {
+		partStart := p.start()
+
+		// Parse productions for reading which includes MATCH, UNWIND and CALL
+		reading, err := p.readingClauses()
+		if err != nil {
+			return nil, err
+		}
+
+		// Parse productions for updating which includes CREATE, MERGE, DELETE, SET and REMOVE.
+		updating, err := p.updatingClauses()
+		if err != nil {
+			return nil, err
+		}
+
+		// A WITH clause ends one part of a multi-part query, and the next part follows it.
+		with, err := p.withClause()
+		if err != nil {
+			return nil, err
+		}
+		if with != nil {
+			parts = append(parts, span(p, partStart, &ast.MultiPartQueryPart{ReadingClause: reading, UpdatingClause: updating, With: with}))
+			continue
+		}
 
-	// Parse productions for reading which includes MATCH, UNWIND and CALL
+		// Parse productions for RETURN. If there were no updating queries then RETURN is required.
+		projection, err := p.parseReturn()
+		if err != nil {
+			return nil, err
+		}
+		if len(updating) == 0 && projection == nil && !p.returnOptional(reading) {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting 'RETURN' following MATCH clause")
+		}
+		query := span(p, partStart, &ast.SinglePartQuery{ReadingClause: reading, UpdatingClause: updating, Projection: projection})
+		if len(parts) == 0 {
+			return query, nil
+		}
+		return span(p, start, &ast.MultiPartQuery{Parts: parts, SinglePartQuery: query}), nil
+	}
+}
+
+// returnOptional reports whether a query part with the given reading clauses, and no updating clauses, may
+// omit RETURN. This is the case within a subquery, and when the query ends with a CALL { ... } subquery.
+func (p *Parser) returnOptional(reading []ast.ReadingClause) bool {
+	if len(reading) == 0 {
+		return false
+	}
+	if p.subqueryDepth > 0 {
+		return true
+	}
+	_, ok := reading[len(reading)-1].(*ast.SubqueryCall)
+	return ok
+}
+
+func (p *Parser) readingClauses() ([]ast.ReadingClause, error) {
+	reading := []ast.ReadingClause{}
 	for {
 		readingClause, err := p.readingClause()
 		if err != nil {
 			return nil, err
 		}
 		if readingClause == nil {
-			break
+			return reading, nil
 		}
 		reading = append(reading, readingClause)
 	}
+}
 
-	// Parse productions for updating which includes CREATE, MERGE, DELETE, SET and REMOVE.
+func (p *Parser) updatingClauses() ([]ast.UpdatingClause, error) {
+	updating := []ast.UpdatingClause{}
 	for {
 		updatingClause, err := p.updatingClause()
 		if err != nil {
 			return nil, err
 		}
 		if updatingClause == nil {
-			break
+			return updating, nil
 		}
 		updating = append(updating, updatingClause)
 	}
+}
 
-	// Parse productions for RETURN. If there were no updating queries then RETURN is required.
-	projection, err := p.parseReturn()
-	if err != nil {
+func (p *Parser) readingClause() (ast.ReadingClause, error) {
+	if clause, err := p.matchClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.unwindClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.loadCSVClause(); err != nil {
 		return nil, err
+	} else if clause != nil {
+		return clause, nil
 	}
-	if len(updating) == 0 && projection == nil {
-		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'RETURN' following MATCH clause")
+	if clause, err := p.subqueryCall(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
 	}
-	return &ast.SinglePartQuery{ReadingClause: reading, UpdatingClause: updating, Projection: projection}, nil
+	return p.inQueryCall()
 }
 
-func (p *Parser) readingClause() (ast.ReadingClause, error) {
-	return p.matchClause()
+func (p *Parser) updatingClause() (ast.UpdatingClause, error) {
+	if clause, err := p.createClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.mergeClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.setClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.removeClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.deleteClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	if clause, err := p.foreachClause(); err != nil {
+		return nil, err
+	} else if clause != nil {
+		return clause, nil
+	}
+	return nil, nil
 }
 
-func (p *Parser) updatingClause() (ast.UpdatingClause, error) {
-	return p.createClause()
+func (p *Parser) withClause() (*ast.WithClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.With); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	projection, err := p.projectionBody()
+	if err != nil {
+		return nil, err
+	}
+	if projection == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting projection following 'WITH'")
+	}
+	if _, ok, err := p.match(scanner.Where); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, &ast.WithClause{Projection: projection}), nil
+	}
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.WithClause{Projection: projection, WhereExpr: expr}), nil
 }
 
+// parseReturn parses a RETURN clause. The span of its projection includes the RETURN.
 func (p *Parser) parseReturn() (*ast.Projection, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.Return); err != nil {
 		return nil, err
 	} else if !ok {
@@ -142,10 +417,11 @@ func (p *Parser) parseReturn() (*ast.Projection, error) {
 	if projection == nil {
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting projection following 'RETURN'")
 	}
-	return projection, nil
+	return span(p, start, projection), nil
 }
 
 func (p *Parser) projectionBody() (*ast.Projection, error) {
+	start := p.start()
 	distinct := false
 	if _, ok, err := p.match(scanner.Distinct); err != nil {
 		return nil, err
@@ -168,10 +444,11 @@ func (p *Parser) projectionBody() (*ast.Projection, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &ast.Projection{Distinct: distinct, Items: items, Order: order, Skip: skip, Limit: limit}, nil
+	return span(p, start, &ast.Projection{Distinct: distinct, Items: items, Order: order, Skip: skip, Limit: limit}), nil
 }
 
 func (p *Parser) projectionItems() (*ast.ProjectionItems, error) {
+	start := p.start()
 	all := false
 	items := []*ast.ProjectionItem{}
 
@@ -201,10 +478,11 @@ func (p *Parser) projectionItems() (*ast.ProjectionItems, error) {
 		}
 		items = append(items, item)
 	}
-	return &ast.ProjectionItems{All: all, Items: items}, nil
+	return span(p, start, &ast.ProjectionItems{All: all, Items: items}), nil
 }
 
 func (p *Parser) projectionItem() (*ast.ProjectionItem, error) {
+	start := p.start()
 	expr, err := p.expr()
 	if err != nil {
 		return nil, err
@@ -212,7 +490,7 @@ func (p *Parser) projectionItem() (*ast.ProjectionItem, error) {
 	if _, ok, err := p.match(scanner.As); err != nil {
 		return nil, err
 	} else if !ok {
-		return &ast.ProjectionItem{Expr: expr}, nil
+		return span(p, start, &ast.ProjectionItem{Expr: expr}), nil
 	}
 
 	// We receive an 'AS' token, so we expect a variable to follow.
@@ -222,10 +500,11 @@ func (p *Parser) projectionItem() (*ast.ProjectionItem, error) {
 	} else if variable == nil {
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable following 'AS'")
 	}
-	return &ast.ProjectionItem{Expr: expr, Variable: variable}, nil
+	return span(p, start, &ast.ProjectionItem{Expr: expr, Variable: variable}), nil
 }
 
 func (p *Parser) order() (*ast.SortOrder, error) {
+	start := p.start()
 	if _, ok, err := p.matchPhrase(scanner.Order, scanner.By); err != nil {
 		return nil, err
 	} else if !ok {
@@ -243,7 +522,7 @@ func (p *Parser) order() (*ast.SortOrder, error) {
 		if _, ok, err := p.match(scanner.Comma); err != nil {
 			return nil, err
 		} else if !ok {
-			return &ast.SortOrder{items}, nil
+			return span(p, start, &ast.SortOrder{Items: items}), nil
 		}
 		item, err := p.sortItem()
 		if err != nil {
@@ -257,6 +536,7 @@ func (p *Parser) order() (*ast.SortOrder, error) {
 }
 
 func (p *Parser) sortItem() (*ast.SortItem, error) {
+	start := p.start()
 	expr, err := p.expr()
 	if err != nil {
 		return nil, err
@@ -275,7 +555,7 @@ func (p *Parser) sortItem() (*ast.SortItem, error) {
 			order = ast.Desc
 		}
 	}
-	return &ast.SortItem{Order: order, Expr: expr}, nil
+	return span(p, start, &ast.SortItem{Order: order, Expr: expr}), nil
 }
 
 func (p *Parser) skip() (ast.Expr, error) {
@@ -305,6 +585,7 @@ func (p *Parser) limit() (ast.Expr, error) {
 }
 
 func (p *Parser) matchClause() (ast.ReadingClause, error) {
+	start := p.start()
 	optional := false
 	if _, ok, err := p.match(scanner.Optional); err != nil {
 		return nil, err
@@ -323,477 +604,2112 @@ func (p *Parser) matchClause() (ast.ReadingClause, error) {
 	if _, ok, err := p.match(scanner.Where); err != nil {
 		return nil, err
 	} else if !ok {
-		return &ast.MatchClause{Optional: optional, Pattern: pattern}, nil
+		return span(p, start, &ast.MatchClause{Optional: optional, Pattern: pattern}), nil
 	}
 	expr, err := p.expr()
 	if err != nil {
 		return nil, err
 	}
-	return &ast.MatchClause{Optional: optional, Pattern: pattern, WhereExpr: expr}, nil
+	return span(p, start, &ast.MatchClause{Optional: optional, Pattern: pattern, WhereExpr: expr}), nil
 }
 
-func (p *Parser) createClause() (ast.UpdatingClause, error) {
-	if _, ok, err := p.match(scanner.Create); err != nil {
+func (p *Parser) unwindClause() (ast.ReadingClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Unwind); err != nil {
 		return nil, err
 	} else if !ok {
 		return nil, nil
 	}
-	pattern, err := p.pattern()
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.As); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'AS' following UNWIND expression")
+	}
+	variable, err := p.variable()
 	if err != nil {
 		return nil, err
+	} else if variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable following 'AS'")
 	}
-	return &ast.CreateClause{Pattern: pattern}, nil
+	return span(p, start, &ast.UnwindClause{Expr: expr, Variable: variable}), nil
 }
 
-func (p *Parser) pattern() (*ast.Pattern, error) {
-	part, err := p.patternPart()
-	if err != nil {
+func (p *Parser) loadCSVClause() (ast.ReadingClause, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("LOAD"); err != nil {
 		return nil, err
+	} else if !ok {
+		return nil, nil
 	}
-	parts := []*ast.PatternPart{part}
-	for {
-		if _, ok, err := p.match(scanner.Comma); err != nil {
+	if _, ok, err := p.matchKeyword("CSV"); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	clause := &ast.LoadCSVClause{}
+	if _, ok, err := p.match(scanner.With); err != nil {
+		return nil, err
+	} else if ok {
+		if _, ok, err := p.matchKeyword("HEADERS"); err != nil {
 			return nil, err
 		} else if !ok {
-			break
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting HEADERS following 'LOAD CSV WITH'")
 		}
-		part, err := p.patternPart()
-		if err != nil {
+		clause.WithHeaders = true
+	}
+	if _, ok, err := p.matchKeyword("FROM"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting FROM following 'LOAD CSV'")
+	}
+	var err error
+	if clause.URL, err = p.expr(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.As); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'AS' following LOAD CSV url")
+	}
+	if clause.Variable, err = p.variable(); err != nil {
+		return nil, err
+	} else if clause.Variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable following 'AS'")
+	}
+	if _, ok, err := p.matchKeyword("FIELDTERMINATOR"); err != nil {
+		return nil, err
+	} else if ok {
+		if t, ok, err := p.match(scanner.String); err != nil {
 			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting string following FIELDTERMINATOR")
+		} else {
+			clause.FieldTerminator = t.Literal.(string)
 		}
-		parts = append(parts, part)
 	}
-	return &ast.Pattern{Parts: parts}, nil
+	return span(p, start, clause), nil
 }
 
-func (p *Parser) patternPart() (*ast.PatternPart, error) {
-	v, err := p.variable()
+func (p *Parser) subqueryCall() (ast.ReadingClause, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("CALL"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.OpenBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	p.subqueryDepth++
+	query, err := p.regularQuery()
+	p.subqueryDepth--
 	if err != nil {
 		return nil, err
 	}
+	if _, ok, err := p.match(scanner.CloseBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '}' following subquery")
+	}
+	call := &ast.SubqueryCall{Query: query}
 
-	// Handle variable assignment pattern part
-	if v != nil {
-		if _, ok, err := p.match(scanner.Equal); err != nil {
+	// Parse the optional IN TRANSACTIONS [OF n ROWS] suffix.
+	pos = p.scanner.Position
+	if _, ok, err := p.match(scanner.In); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, call), nil
+	}
+	if _, ok, err := p.matchKeyword("TRANSACTIONS"); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return span(p, start, call), nil
+	}
+	call.InTransactions = true
+	if _, ok, err := p.match(scanner.Of); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, call), nil
+	}
+	if call.BatchSize, err = p.expr(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.matchKeyword("ROWS"); err != nil {
+		return nil, err
+	} else if !ok {
+		if _, ok, err := p.matchKeyword("ROW"); err != nil {
 			return nil, err
 		} else if !ok {
-			return nil, p.reporter.Error(p.scanner.Line(), "expecting '=' following variable")
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting ROWS following batch size")
 		}
 	}
+	return span(p, start, call), nil
+}
 
-	// Handle anonymous part
-	part, err := p.anonymousPatternPart()
-	if err != nil {
+func (p *Parser) inQueryCall() (ast.ReadingClause, error) {
+	start := p.start()
+	if _, ok, err := p.matchKeyword("CALL"); err != nil {
 		return nil, err
+	} else if !ok {
+		return nil, nil
 	}
-	return &ast.PatternPart{Variable: v, Element: part}, nil
-}
-
-func (p *Parser) anonymousPatternPart() (ast.PatternElement, error) {
-	return p.patternElement()
-}
-
-func (p *Parser) patternElement() (ast.PatternElement, error) {
-	node, err := p.nodePattern()
+	procedure, err := p.procedureInvocation(false)
 	if err != nil {
 		return nil, err
 	}
-
-	// First handle the nested PatternElement production
-	if node == nil {
+	call := &ast.InQueryCall{Procedure: procedure}
+	if _, ok, err := p.matchKeyword("YIELD"); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, call), nil
+	}
+	if call.YieldItems, err = p.yieldItems(); err != nil {
+		return nil, err
+	}
+	return span(p, start, call), nil
+}
+
+// procedureInvocation parses the procedure name and its argument list. When implicit is true, the argument
+// list may be omitted, which is only allowed in a standalone call.
+func (p *Parser) procedureInvocation(implicit bool) (*ast.ProcedureInvocation, error) {
+	start := p.start()
+	ns, err := p.namespace()
+	if err != nil {
+		return nil, err
+	}
+	name, err := p.symbolicName()
+	if err != nil {
+		return nil, err
+	}
+	if name == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting procedure name")
+	}
+	procedure := &ast.ProcedureInvocation{ProcedureName: span(p, start, &ast.SymbolicFunctionName{Namespace: ns, FunctionName: name})}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if !ok {
+		if !implicit {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting '(' following procedure name")
+		}
+		procedure.Implicit = true
+		return span(p, start, procedure), nil
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if ok {
+		return span(p, start, procedure), nil
+	}
+	for {
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting procedure argument")
+		}
+		procedure.Args = append(procedure.Args, expr)
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following procedure arguments")
+	}
+	return span(p, start, procedure), nil
+}
+
+func (p *Parser) yieldItems() (*ast.YieldItems, error) {
+	start := p.start()
+	items := &ast.YieldItems{}
+	for {
+		item, err := p.yieldItem()
+		if err != nil {
+			return nil, err
+		}
+		if item == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting yield item")
+		}
+		items.Items = append(items.Items, item)
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+	if _, ok, err := p.match(scanner.Where); err != nil {
+		return nil, err
+	} else if ok {
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if expr == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting expression following WHERE")
+		}
+		items.WhereExpr = expr
+	}
+	return span(p, start, items), nil
+}
+
+func (p *Parser) yieldItem() (*ast.YieldItem, error) {
+	start := p.start()
+	name, err := p.symbolicName()
+	if err != nil {
+		return nil, err
+	}
+	if name == nil {
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.As); err != nil {
+		return nil, err
+	} else if !ok {
+		return span(p, start, &ast.YieldItem{Variable: name}), nil
+	}
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+	if variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable following AS")
+	}
+	return span(p, start, &ast.YieldItem{Field: name, Variable: variable}), nil
+}
+
+func (p *Parser) createClause() (ast.UpdatingClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Create); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	pattern, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.CreateClause{Pattern: pattern}), nil
+}
+
+func (p *Parser) mergeClause() (ast.UpdatingClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Merge); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	part, err := p.patternPart()
+	if err != nil {
+		return nil, err
+	}
+	actions := []*ast.MergeAction{}
+	for {
+		action, err := p.mergeAction()
+		if err != nil {
+			return nil, err
+		}
+		if action == nil {
+			break
+		}
+		actions = append(actions, action)
+	}
+	return span(p, start, &ast.MergeClause{PatternPart: part, Actions: actions}), nil
+}
+
+func (p *Parser) mergeAction() (*ast.MergeAction, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.On); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	action := &ast.MergeAction{}
+	if t, ok, err := p.match(scanner.Match, scanner.Create); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'MATCH' or 'CREATE' following 'ON'")
+	} else if t.T == scanner.Create {
+		action.Type = ast.OnCreate
+	}
+	set, err := p.setClause()
+	if err != nil {
+		return nil, err
+	}
+	if set == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'SET' following merge action")
+	}
+	action.Set = set
+	return span(p, start, action), nil
+}
+
+func (p *Parser) foreachClause() (*ast.ForeachClause, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("FOREACH"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	} else if variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable following 'FOREACH ('")
+	}
+	if _, ok, err := p.match(scanner.In); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'IN' following FOREACH variable")
+	}
+	p.pipeDepth++
+	expr, err := p.expr()
+	p.pipeDepth--
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.Pipe); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '|' following FOREACH list")
+	}
+
+	// Only updating clauses may appear in the body, so anything else before the ')' is an error.
+	clauses, err := p.updatingClauses()
+	if err != nil {
+		return nil, err
+	}
+	if len(clauses) == 0 {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting updating clause following '|'")
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting updating clause or ')' in FOREACH")
+	}
+	return span(p, start, &ast.ForeachClause{Variable: variable, Expr: expr, UpdatingClauses: clauses}), nil
+}
+
+func (p *Parser) setClause() (*ast.SetClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Set); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	item, err := p.setItem()
+	if err != nil {
+		return nil, err
+	}
+	items := []ast.SetItem{item}
+	for {
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			return span(p, start, &ast.SetClause{Items: items}), nil
+		}
+		item, err := p.setItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func (p *Parser) setItem() (ast.SetItem, error) {
+	// The variable forms 'n = map', 'n += map' and 'n:Label' are checked first. If the variable is not followed
+	// by one of them, this is a property form such as 'n.prop = expr', which is parsed from the start again.
+	pos := p.scanner.Position
+	start := p.start()
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+	if variable != nil {
+		if t, ok, err := p.match(scanner.Equal, scanner.PlusEqual); err != nil {
+			return nil, err
+		} else if ok {
+			expr, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if t.T == scanner.PlusEqual {
+				return span(p, start, &ast.VariableAddSetItem{Variable: variable, Expr: expr}), nil
+			}
+			return span(p, start, &ast.VariableSetItem{Variable: variable, Expr: expr}), nil
+		}
+		labels, err := p.NodeLabels()
+		if err != nil {
+			return nil, err
+		}
+		if len(labels) > 0 {
+			return span(p, start, &ast.LabelsSetItem{Variable: variable, Labels: labels}), nil
+		}
+	}
+	p.scanner.Position = pos
+	property, err := p.propertyExpression()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.Equal); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '=' following property")
+	}
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.PropertySetItem{Property: property, Expr: expr}), nil
+}
+
+func (p *Parser) removeClause() (*ast.RemoveClause, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Remove); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	item, err := p.removeItem()
+	if err != nil {
+		return nil, err
+	}
+	items := []ast.RemoveItem{item}
+	for {
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			return span(p, start, &ast.RemoveClause{Items: items}), nil
+		}
+		item, err := p.removeItem()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+func (p *Parser) removeItem() (ast.RemoveItem, error) {
+	// A variable followed by node labels removes labels, anything else is a property expression, which is
+	// parsed from the start again.
+	pos := p.scanner.Position
+	start := p.start()
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+	if variable != nil {
+		labels, err := p.NodeLabels()
+		if err != nil {
+			return nil, err
+		}
+		if len(labels) > 0 {
+			return span(p, start, &ast.LabelsRemoveItem{Variable: variable, Labels: labels}), nil
+		}
+	}
+	p.scanner.Position = pos
+	property, err := p.propertyExpression()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.PropertyRemoveItem{Property: property}), nil
+}
+
+func (p *Parser) deleteClause() (*ast.DeleteClause, error) {
+	start := p.start()
+	_, detach, err := p.match(scanner.Detach)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.Delete); err != nil {
+		return nil, err
+	} else if !ok {
+		if detach {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting 'DELETE' following 'DETACH'")
+		}
+		return nil, nil
+	}
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	exprs := []ast.Expr{expr}
+	for {
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			return span(p, start, &ast.DeleteClause{Detach: detach, Exprs: exprs}), nil
+		}
+		expr, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+}
+
+func (p *Parser) schemaCommand() (ast.SchemaCommand, error) {
+	command, err := p.constraintCommand()
+	if err != nil {
+		return nil, err
+	}
+	if command == nil {
+		if command, err = p.indexCommand(); err != nil {
+			return nil, err
+		} else if command == nil {
+			return nil, nil
+		}
+	}
+	if !p.atEndOfInput() {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting end of schema command")
+	}
+	return command, nil
+}
+
+func (p *Parser) constraintCommand() (ast.SchemaCommand, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	t, ok, err := p.match(scanner.Create, scanner.Drop)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.Constraint); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if t.T == scanner.Create {
+		return p.createConstraint(start)
+	}
+	return p.dropConstraint(start)
+}
+
+func (p *Parser) indexCommand() (ast.SchemaCommand, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	t, ok, err := p.match(scanner.Create, scanner.Drop)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	if _, ok, err := p.matchKeyword("INDEX"); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if t.T == scanner.Create {
+		return p.createIndex(start)
+	}
+	return p.dropIndex(start)
+}
+
+func (p *Parser) createIndex(start scanner.Pos) (*ast.CreateIndex, error) {
+	var err error
+	command := &ast.CreateIndex{}
+	if command.Name, command.IfNotExists, err = p.schemaCommandName(true); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.On); err != nil {
+		return nil, err
+	} else if ok {
+		if command.Index, err = p.labelIndexDefinition(); err != nil {
+			return nil, err
+		}
+		return span(p, start, command), nil
+	}
+	if _, ok, err := p.match(scanner.For); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting FOR or ON following INDEX")
+	}
+	indexStart := p.start()
+	index := &ast.IndexDefinition{}
+	if index.Variable, index.Label, index.Relationship, err = p.schemaPattern(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.On); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting ON following index pattern")
+	}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '(' preceding index properties")
+	}
+	if index.Properties, err = p.schemaProperties(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following index properties")
+	}
+	command.Index = span(p, indexStart, index)
+	return span(p, start, command), nil
+}
+
+func (p *Parser) dropIndex(start scanner.Pos) (*ast.DropIndex, error) {
+	var err error
+	command := &ast.DropIndex{}
+	if _, ok, err := p.match(scanner.On); err != nil {
+		return nil, err
+	} else if ok {
+		if command.Index, err = p.labelIndexDefinition(); err != nil {
+			return nil, err
+		}
+		return span(p, start, command), nil
+	}
+	if command.Name, err = p.symbolicName(); err != nil {
+		return nil, err
+	}
+	if command.Name == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting index name or ON following INDEX")
+	}
+	if command.IfExists, err = p.ifExists(false); err != nil {
+		return nil, err
+	}
+	return span(p, start, command), nil
+}
+
+// labelIndexDefinition parses the :Label(property, ...) form of an index definition.
+func (p *Parser) labelIndexDefinition() (*ast.IndexDefinition, error) {
+	start := p.start()
+	label, err := p.NodeLabel()
+	if err != nil {
+		return nil, err
+	}
+	if label == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting label following ON")
+	}
+	index := &ast.IndexDefinition{Label: label}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '(' following label")
+	}
+	for {
+		property, err := p.schemaName()
+		if err != nil {
+			return nil, err
+		}
+		if property == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting property key name")
+		}
+		index.Properties = append(index.Properties, property)
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following property key names")
+	}
+	return span(p, start, index), nil
+}
+
+func (p *Parser) createConstraint(start scanner.Pos) (*ast.CreateConstraint, error) {
+	var err error
+	command := &ast.CreateConstraint{}
+	if command.Name, command.IfNotExists, err = p.schemaCommandName(true); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.For, scanner.On); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting FOR or ON following CONSTRAINT")
+	}
+	if command.Constraint, err = p.constraintDefinition(); err != nil {
+		return nil, err
+	}
+	return span(p, start, command), nil
+}
+
+func (p *Parser) dropConstraint(start scanner.Pos) (*ast.DropConstraint, error) {
+	var err error
+	command := &ast.DropConstraint{}
+	if _, ok, err := p.match(scanner.On); err != nil {
+		return nil, err
+	} else if ok {
+		if command.Constraint, err = p.constraintDefinition(); err != nil {
+			return nil, err
+		}
+		return span(p, start, command), nil
+	}
+	if command.Name, err = p.symbolicName(); err != nil {
+		return nil, err
+	}
+	if command.Name == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting constraint name or ON following CONSTRAINT")
+	}
+	if command.IfExists, err = p.ifExists(false); err != nil {
+		return nil, err
+	}
+	return span(p, start, command), nil
+}
+
+// constraintDefinition parses the pattern and predicate of a constraint, accepting both the REQUIRE form and
+// the older ASSERT form.
+func (p *Parser) constraintDefinition() (*ast.ConstraintDefinition, error) {
+	start := p.start()
+	var err error
+	constraint := &ast.ConstraintDefinition{}
+	if constraint.Variable, constraint.Label, constraint.Relationship, err = p.schemaPattern(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.Require); err != nil {
+		return nil, err
+	} else if !ok {
+		if _, ok, err := p.matchKeyword("ASSERT"); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting REQUIRE following constraint pattern")
+		}
+	}
+
+	// The older existence constraint form is ASSERT exists(n.property).
+	if _, ok, err := p.match(scanner.Exists); err != nil {
+		return nil, err
+	} else if ok {
 		if _, ok, err := p.match(scanner.OpenParen); err != nil {
 			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting '(' following EXISTS")
+		}
+		property, err := p.schemaProperty()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok, err := p.match(scanner.CloseParen); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following property")
+		}
+		constraint.Type = ast.ExistenceConstraint
+		constraint.Properties = []ast.SchemaName{property}
+		return span(p, start, constraint), nil
+	}
+
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if ok {
+		if constraint.Properties, err = p.schemaProperties(); err != nil {
+			return nil, err
+		}
+		if _, ok, err := p.match(scanner.CloseParen); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following constraint properties")
+		}
+	} else {
+		property, err := p.schemaProperty()
+		if err != nil {
+			return nil, err
+		}
+		constraint.Properties = []ast.SchemaName{property}
+	}
+	if _, ok, err := p.matchPhrase(scanner.Is, scanner.Unique); err != nil {
+		return nil, err
+	} else if ok {
+		constraint.Type = ast.UniqueConstraint
+		return span(p, start, constraint), nil
+	}
+	if _, ok, err := p.matchPhrase(scanner.Is, scanner.Not, scanner.Null); err != nil {
+		return nil, err
+	} else if ok {
+		constraint.Type = ast.ExistenceConstraint
+		return span(p, start, constraint), nil
+	}
+	return nil, p.reporter.Error(p.scanner.Line(), "expecting IS UNIQUE or IS NOT NULL")
+}
+
+// schemaCommandName parses the optional name of an index or constraint, followed by an optional IF NOT EXISTS.
+func (p *Parser) schemaCommandName(not bool) (ast.SymbolicName, bool, error) {
+	if ok, err := p.ifExists(not); err != nil {
+		return nil, false, err
+	} else if ok {
+		return nil, true, nil
+	}
+	name, err := p.symbolicName()
+	if err != nil {
+		return nil, false, err
+	}
+	ok, err := p.ifExists(not)
+	if err != nil {
+		return nil, false, err
+	}
+	return name, ok, nil
+}
+
+// ifExists matches IF EXISTS or, when not is true, IF NOT EXISTS.
+func (p *Parser) ifExists(not bool) (bool, error) {
+	pos := p.scanner.Position
+	if _, ok, err := p.matchKeyword("IF"); err != nil {
+		return false, err
+	} else if !ok {
+		return false, nil
+	}
+	if not {
+		if _, ok, err := p.match(scanner.Not); err != nil {
+			return false, err
+		} else if !ok {
+			p.scanner.Position = pos
+			return false, nil
+		}
+	}
+	if _, ok, err := p.match(scanner.Exists); err != nil {
+		return false, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return false, nil
+	}
+	return true, nil
+}
+
+// schemaPattern parses the node pattern (n:Label), or the relationship pattern ()-[r:TYPE]-(), that an index
+// or constraint applies to.
+func (p *Parser) schemaPattern() (ast.SymbolicName, ast.SchemaName, bool, error) {
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting '('")
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, nil, false, err
+	} else if ok {
+		return p.schemaRelationshipPattern()
+	}
+	variable, label, err := p.schemaVariableAndLabel()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting ')' following label")
+	}
+	return variable, label, false, nil
+}
+
+func (p *Parser) schemaRelationshipPattern() (ast.SymbolicName, ast.SchemaName, bool, error) {
+	if _, ok, err := p.match(scanner.LessThan); err != nil {
+		return nil, nil, false, err
+	} else if ok {
+		if _, ok, err := p.match(scanner.Dash); err != nil {
+			return nil, nil, false, err
+		} else if !ok {
+			return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting '-' following '<'")
+		}
+	} else if _, ok, err := p.match(scanner.Dash); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting relationship pattern")
+	}
+	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting '['")
+	}
+	variable, relType, err := p.schemaVariableAndLabel()
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if _, ok, err := p.match(scanner.CloseBracket); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting ']' following relationship type")
+	}
+	if _, ok, err := p.match(scanner.Dash); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting '-' following ']'")
+	}
+	// Either direction is accepted, constraints and indexes do not depend on it.
+	if _, _, err := p.match(scanner.GreaterThan); err != nil {
+		return nil, nil, false, err
+	}
+	if _, ok, err := p.matchPhrase(scanner.OpenParen, scanner.CloseParen); err != nil {
+		return nil, nil, false, err
+	} else if !ok {
+		return nil, nil, false, p.reporter.Error(p.scanner.Line(), "expecting '()' following relationship")
+	}
+	return variable, relType, true, nil
+}
+
+func (p *Parser) schemaVariableAndLabel() (ast.SymbolicName, ast.SchemaName, error) {
+	variable, err := p.variable()
+	if err != nil {
+		return nil, nil, err
+	}
+	if variable == nil {
+		return nil, nil, p.reporter.Error(p.scanner.Line(), "expecting variable")
+	}
+	label, err := p.NodeLabel()
+	if err != nil {
+		return nil, nil, err
+	}
+	if label == nil {
+		return nil, nil, p.reporter.Error(p.scanner.Line(), "expecting label or relationship type")
+	}
+	return variable, label, nil
+}
+
+// schemaProperties parses a comma separated list of variable.property terms.
+func (p *Parser) schemaProperties() ([]ast.SchemaName, error) {
+	properties := []ast.SchemaName{}
+	for {
+		property, err := p.schemaProperty()
+		if err != nil {
+			return nil, err
+		}
+		properties = append(properties, property)
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+	return properties, nil
+}
+
+// schemaProperty parses a variable.property term and returns the property key name.
+func (p *Parser) schemaProperty() (ast.SchemaName, error) {
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+	if variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting variable")
+	}
+	property, err := p.propertyLookup()
+	if err != nil {
+		return nil, err
+	}
+	if property == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting property lookup")
+	}
+	return property, nil
+}
+
+func (p *Parser) pattern() (*ast.Pattern, error) {
+	start := p.start()
+	part, err := p.patternPart()
+	if err != nil {
+		return nil, err
+	}
+	parts := []*ast.PatternPart{part}
+	for {
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		part, err := p.patternPart()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+	return span(p, start, &ast.Pattern{Parts: parts}), nil
+}
+
+func (p *Parser) patternPart() (*ast.PatternPart, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	v, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle variable assignment pattern part. Without the '=' the identifier may instead start a shortest path
+	// pattern, so it is left for the anonymous part.
+	if v != nil {
+		if _, ok, err := p.match(scanner.Equal); err != nil {
+			return nil, err
+		} else if !ok {
+			v = nil
+			p.scanner.Position = pos
+		}
+	}
+
+	selector, err := p.pathSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	// Handle anonymous part
+	part, err := p.anonymousPatternPart()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.PatternPart{Variable: v, Selector: selector, Element: part}), nil
+}
+
+// pathSelector parses the optional ANY SHORTEST, ALL SHORTEST or SHORTEST k preceding a path pattern.
+func (p *Parser) pathSelector() (*ast.PathSelector, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("ANY"); err != nil {
+		return nil, err
+	} else if ok {
+		if _, ok, err := p.matchKeyword("SHORTEST"); err != nil {
+			return nil, err
+		} else if ok {
+			return span(p, start, &ast.PathSelector{Kind: ast.AnyShortestPath}), nil
+		}
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.All); err != nil {
+		return nil, err
+	} else if ok {
+		if _, ok, err := p.matchKeyword("SHORTEST"); err != nil {
+			return nil, err
+		} else if ok {
+			return span(p, start, &ast.PathSelector{Kind: ast.AllShortestPaths}), nil
+		}
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if _, ok, err := p.matchKeyword("SHORTEST"); err != nil {
+		return nil, err
+	} else if ok {
+		if t, ok, err := p.match(scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger); err != nil {
+			return nil, err
+		} else if ok {
+			return span(p, start, &ast.PathSelector{Kind: ast.ShortestKPaths, Count: t.Literal.(int64)}), nil
+		}
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting number of paths following 'SHORTEST'")
+	}
+	return nil, nil
+}
+
+func (p *Parser) anonymousPatternPart() (ast.PatternElement, error) {
+	if pattern, err := p.shortestPathPattern(); err != nil {
+		return nil, err
+	} else if pattern != nil {
+		return pattern, nil
+	}
+	return p.patternElement()
+}
+
+func (p *Parser) shortestPathPattern() (*ast.ShortestPathPattern, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	t, ok, err := p.match(scanner.Identifier)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	pattern := &ast.ShortestPathPattern{}
+	switch strings.ToUpper(t.Lexeme) {
+	case "SHORTESTPATH":
+	case "ALLSHORTESTPATHS":
+		pattern.All = true
+	default:
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	if pattern.Element, err = p.patternElement(); err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.CloseParen); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following shortest path pattern")
+	}
+	if err := p.checkShortestPathElement(pattern.Element); err != nil {
+		return nil, err
+	}
+	return span(p, start, pattern), nil
+}
+
+// checkShortestPathElement checks that a shortest path pattern is a single relationship between two nodes,
+// and that a variable length relationship has a minimum length of no more than one.
+func (p *Parser) checkShortestPathElement(element ast.PatternElement) error {
+	for {
+		nested, ok := element.(*ast.PatternElementNested)
+		if !ok || nested.Quantifier != nil {
+			break
+		}
+		element = nested.Element
+	}
+	pattern, ok := element.(*ast.PatternElementPattern)
+	if !ok || len(pattern.Chain) != 1 {
+		return p.reporter.Error(p.scanner.Line(), "shortest path pattern must contain a single relationship")
+	}
+	detail := pattern.Chain[0].RelationshipPattern.RelationshipDetail
+	if detail != nil && detail.RangeLiteral != nil && detail.RangeLiteral.Begin > 1 {
+		return p.reporter.Error(p.scanner.Line(), "shortest path pattern must have a minimum length of 0 or 1")
+	}
+	return nil
+}
+
+// patternElement parses a path pattern. Elements are juxtaposed only alongside a parenthesized path pattern,
+// as in (a) ((b)-[:R]->(c))+ (d).
+func (p *Parser) patternElement() (ast.PatternElement, error) {
+	start := p.start()
+	element, err := p.pathFactor()
+	if err != nil {
+		return nil, err
+	}
+	elements := []ast.PatternElement{element}
+	for p.peek().T == scanner.OpenParen {
+		if _, ok := element.(*ast.PatternElementNested); !ok && !p.atParenthesizedPath() {
+			break
+		}
+		if element, err = p.pathFactor(); err != nil {
+			return nil, err
+		}
+		elements = append(elements, element)
+	}
+	if len(elements) == 1 {
+		return element, nil
+	}
+	return span(p, start, &ast.PatternElementSequence{Elements: elements}), nil
+}
+
+// atParenthesizedPath reports whether the next tokens start a parenthesized path pattern rather than a node
+// pattern, without consuming any.
+func (p *Parser) atParenthesizedPath() bool {
+	pos := p.scanner.Position
+	defer func() { p.scanner.Position = pos }()
+	return p.scanner.NextToken().T == scanner.OpenParen && p.scanner.NextToken().T == scanner.OpenParen
+}
+
+func (p *Parser) pathFactor() (ast.PatternElement, error) {
+	start := p.start()
+	// First handle the nested PatternElement production
+	if p.atParenthesizedPath() {
+		if _, _, err := p.match(scanner.OpenParen); err != nil {
+			return nil, err
+		}
+		element, err := p.patternElement()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok, err := p.match(scanner.CloseParen); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following nested pattern element")
+		}
+		quantifier, err := p.quantifier()
+		if err != nil {
+			return nil, err
+		}
+		return span(p, start, &ast.PatternElementNested{Element: element, Quantifier: quantifier}), nil
+	}
+
+	// Else handle the chained PatternElement production
+	node, err := p.nodePattern()
+	if err != nil {
+		return nil, err
+	} else if node == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting pattern element")
+	}
+	chainList := []*ast.PatternElementChain{}
+	for {
+		chain, err := p.patternElementChain()
+		if err != nil {
+			return nil, err
+		} else if chain == nil {
+			break
+		}
+		chainList = append(chainList, chain)
+	}
+	return span(p, start, &ast.PatternElementPattern{Left: node, Chain: chainList}), nil
+}
+
+// quantifier parses the optional quantifier of a parenthesized path pattern: '+', '*', {n}, {m,n}, {m,} or {,n}.
+func (p *Parser) quantifier() (*ast.RangeLiteral, error) {
+	start := p.start()
+	if t, ok, err := p.match(scanner.Plus, scanner.Star); err != nil {
+		return nil, err
+	} else if ok {
+		if t.T == scanner.Plus {
+			return span(p, start, &ast.RangeLiteral{Begin: 1, End: math.MaxInt64}), nil
+		}
+		return span(p, start, &ast.RangeLiteral{Begin: 0, End: math.MaxInt64}), nil
+	}
+	if _, ok, err := p.match(scanner.OpenBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	quantifier := &ast.RangeLiteral{Begin: 0, End: math.MaxInt64}
+	t, lower, err := p.match(scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger)
+	if err != nil {
+		return nil, err
+	} else if lower {
+		quantifier.Begin = t.Literal.(int64)
+	}
+	if _, ok, err := p.match(scanner.Comma); err != nil {
+		return nil, err
+	} else if ok {
+		if t, ok, err := p.match(scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger); err != nil {
+			return nil, err
+		} else if ok {
+			quantifier.End = t.Literal.(int64)
+		}
+	} else if lower {
+		quantifier.End = quantifier.Begin
+	} else {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting number of repetitions in quantifier")
+	}
+	if _, ok, err := p.match(scanner.CloseBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '}' following quantifier")
+	}
+	if quantifier.Begin > quantifier.End {
+		return nil, p.reporter.Error(p.scanner.Line(), "quantifier minimum is greater than its maximum")
+	}
+	return span(p, start, quantifier), nil
+}
+
+func (p *Parser) expr() (ast.Expr, error) {
+	return p.orExpr()
+}
+
+func (p *Parser) orExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.xorExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, ok, err := p.match(scanner.Or)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.xorExpr()
+			if err != nil {
+				return nil, err
+			}
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: ast.Or, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) xorExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.andExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, ok, err := p.match(scanner.Xor)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.andExpr()
+			if err != nil {
+				return nil, err
+			}
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: ast.Xor, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) andExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.notExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		_, ok, err := p.match(scanner.And)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.notExpr()
+			if err != nil {
+				return nil, err
+			}
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: ast.And, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) notExpr() (ast.Expr, error) {
+	start := p.start()
+	not := false
+	for {
+		_, ok, err := p.match(scanner.Not)
+		if err != nil {
+			return nil, err
 		} else if ok {
-			element, err := p.patternElement()
+			not = !not
+		} else {
+			break
+		}
+	}
+	expr, err := p.comparisonExpr()
+	if err != nil {
+		return nil, err
+	}
+	if not {
+		expr = span(p, start, &ast.UnaryExpr{Op: ast.Not, Expr: expr})
+	}
+	return expr, nil
+}
+
+func (p *Parser) comparisonExpr() (ast.Expr, error) {
+	start := p.start()
+	tokenTypes := []scanner.TokenType{
+		scanner.Equal,
+		scanner.NotEqual,
+		scanner.LessThan,
+		scanner.GreaterThan,
+		scanner.LessThanOrEqual,
+		scanner.GreaterThanOrEqual,
+	}
+	expr, err := p.addOrSubtractExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok, err := p.match(tokenTypes...)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.addOrSubtractExpr()
+			if err != nil {
+				return nil, err
+			}
+			op, _ := opForTokens[t.T]
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: op, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) addOrSubtractExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.multiplyDivideModuloExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok, err := p.match(scanner.Plus, scanner.Dash)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.multiplyDivideModuloExpr()
+			if err != nil {
+				return nil, err
+			}
+			op, _ := opForTokens[t.T]
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: op, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) multiplyDivideModuloExpr() (ast.Expr, error) {
+	start := p.start()
+	tokenTypes := []scanner.TokenType{
+		scanner.Star,
+		scanner.ForwardSlash,
+		scanner.Percent,
+	}
+	expr, err := p.powerExpr()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok, err := p.match(tokenTypes...)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.powerExpr()
+			if err != nil {
+				return nil, err
+			}
+			op, _ := opForTokens[t.T]
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: op, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) powerExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.unaryAddOrSubtract()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t, ok, err := p.match(scanner.Caret)
+		switch {
+		case err != nil:
+			return nil, err
+		case ok:
+			right, err := p.unaryAddOrSubtract()
 			if err != nil {
 				return nil, err
-			} else if element == nil {
-				return nil, p.reporter.Error(p.scanner.Line(), "expecting nested pattern element")
 			}
-			return &ast.PatternElementNested{Element: element}, nil
+			op, _ := opForTokens[t.T]
+			expr = span(p, start, &ast.BinaryExpr{Left: expr, Op: op, Right: right})
+		default:
+			return expr, nil
+		}
+	}
+}
+
+func (p *Parser) unaryAddOrSubtract() (ast.Expr, error) {
+	start := p.start()
+	tokenTypes := []scanner.TokenType{
+		scanner.Plus,
+		scanner.Dash,
+	}
+	negate := false
+	for {
+		t, ok, err := p.match(tokenTypes...)
+		if err != nil {
+			return nil, err
+		} else if ok {
+			if t.T == scanner.Dash {
+				negate = !negate
+			}
+		} else {
+			break
+		}
+	}
+	expr, err := p.stringListNullOperatorExpr()
+	if err != nil {
+		return nil, err
+	}
+	if negate {
+		expr = span(p, start, &ast.UnaryExpr{Op: ast.Negate, Expr: expr})
+	}
+	return expr, nil
+}
+
+func (p *Parser) stringListNullOperatorExpr() (ast.Expr, error) {
+	start := p.start()
+	expr, err := p.propertyOrLabelsExpr()
+	if err != nil {
+		return nil, err
+	}
+	listStart := p.start()
+	list := []ast.Expr{}
+	for {
+		if expr, err := p.stringOpExpr(); err != nil {
+			return nil, err
+		} else if expr != nil {
+			list = append(list, expr)
+			continue
+		}
+		if expr, err := p.listOpExpr(); err != nil {
+			return nil, err
+		} else if expr != nil {
+			list = append(list, expr)
+			continue
+		}
+		if expr, err := p.isNullExpr(); err != nil {
+			return nil, err
+		} else if expr != nil {
+			list = append(list, expr)
+			continue
+		}
+		if expr, err := p.typePredicateExpr(); err != nil {
+			return nil, err
+		} else if expr != nil {
+			list = append(list, expr)
+			continue
+		}
+		break
+	}
+	if len(list) > 0 {
+		return span(p, start, &ast.BinaryExpr{Left: expr, Op: ast.StringOrListOp, Right: span(p, listStart, &ast.ListExpr{List: list})}), nil
+	}
+	return expr, nil
+}
+
+func (p *Parser) propertyOrLabelsExpr() (ast.Expr, error) {
+	start := p.start()
+	atom, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	properties := []ast.SchemaName{}
+	for {
+		property, err := p.propertyLookup()
+		if err != nil {
+			return nil, err
+		} else if property != nil {
+			properties = append(properties, property)
+		} else {
+			break
+		}
+	}
+	if len(properties) == 0 {
+		properties = nil
+	}
+	labels, labelExpr, err := p.labels()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.PropertyLabelsExpr{Atom: atom, PropertyKeys: properties, Labels: labels, LabelExpr: labelExpr}), nil
+}
+
+func (p *Parser) propertyExpression() (ast.Expr, error) {
+	start := p.start()
+	atom, err := p.atom()
+	if err != nil {
+		return nil, err
+	}
+	properties := []ast.SchemaName{}
+	for {
+		property, err := p.propertyLookup()
+		if err != nil {
+			return nil, err
+		} else if property != nil {
+			properties = append(properties, property)
 		} else {
-			return nil, p.reporter.Error(p.scanner.Line(), "expecting pattern element")
+			break
+		}
+	}
+	if len(properties) == 0 {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting property lookup")
+	}
+	return span(p, start, &ast.PropertyLabelsExpr{Atom: atom, PropertyKeys: properties}), nil
+}
+
+func (p *Parser) propertyLookup() (ast.SchemaName, error) {
+	if _, ok, err := p.match(scanner.Period); err != nil {
+		return nil, err
+	} else if ok {
+		sn, err := p.schemaName()
+		if err != nil {
+			return nil, err
+		}
+		if sn != nil {
+			return sn, nil
 		}
 	}
+	return nil, nil
+}
+
+func (p *Parser) schemaName() (ast.SchemaName, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	t := p.scanner.NextToken()
+	if _, ok := scanner.ReservedWordTokens[t.T]; ok {
+		return span(p, start, &ast.ReservedWordSchemaName{TokenType: t.T}), nil
+	}
+	p.scanner.Position = pos
+	name, err := p.symbolicName()
+	if err != nil {
+		return nil, err
+	}
+	if name != nil {
+		return span(p, start, &ast.SymbolicNameSchemaName{SymbolicName: name}), nil
+	}
+	return nil, nil
+}
 
-	// Else handle the chained PatternElement production
-	chainList := []*ast.PatternElementChain{}
+// labels parses the labels of a node pattern or label predicate. Simple labels, :A:B, are returned as a list
+// of names, any other label expression, such as :A|B or :!%, is returned as a LabelExpr. A name in
+// parentheses, :(A), is a simple label. The two forms cannot be mixed, as in :A:B|C or :A|B:C.
+func (p *Parser) labels() ([]ast.SchemaName, ast.LabelExpr, error) {
+	labels := []ast.SchemaName{}
 	for {
-		chain, err := p.patternElementChain()
+		if _, ok, err := p.match(scanner.Colon); err != nil {
+			return nil, nil, err
+		} else if !ok {
+			return labels, nil, nil
+		}
+		expr, err := p.labelExpression()
 		if err != nil {
-			return nil, err
-		} else if chain == nil {
-			break
+			return nil, nil, err
 		}
-		chainList = append(chainList, chain)
+		if name, ok := expr.(*ast.LabelName); ok {
+			labels = append(labels, name.Name)
+			continue
+		}
+		if len(labels) > 0 || p.peek().T == scanner.Colon {
+			return nil, nil, p.reporter.Error(p.scanner.Line(), "cannot mix ':' label conjunction with label expression operators")
+		}
+		return nil, expr, nil
 	}
-	return &ast.PatternElementPattern{Left: node, Chain: chainList}, nil
-}
-
-func (p *Parser) expr() (ast.Expr, error) {
-	return p.orExpr()
 }
 
-func (p *Parser) orExpr() (ast.Expr, error) {
-	expr, err := p.xorExpr()
+// labelExpression parses a label expression. From lowest to highest precedence the operators are '|',
+// '&' and '!'. A disjunct may repeat the leading ':', as in :A|:B.
+func (p *Parser) labelExpression() (ast.LabelExpr, error) {
+	start := p.start()
+	left, err := p.labelConjunction()
 	if err != nil {
 		return nil, err
 	}
-	for {
-		_, ok, err := p.match(scanner.Or)
-		switch {
-		case err != nil:
+	for p.pipeDepth == 0 {
+		if _, ok, err := p.match(scanner.Pipe); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+		if _, _, err := p.match(scanner.Colon); err != nil {
 			return nil, err
-		case ok:
-			right, err := p.xorExpr()
-			if err != nil {
-				return nil, err
-			}
-			expr = &ast.BinaryExpr{expr, ast.Or, right}
-		default:
-			return expr, nil
 		}
+		right, err := p.labelConjunction()
+		if err != nil {
+			return nil, err
+		}
+		left = span(p, start, &ast.LabelOr{Left: left, Right: right})
 	}
+	return left, nil
 }
 
-func (p *Parser) xorExpr() (ast.Expr, error) {
-	expr, err := p.andExpr()
+func (p *Parser) labelConjunction() (ast.LabelExpr, error) {
+	start := p.start()
+	left, err := p.labelNegation()
 	if err != nil {
 		return nil, err
 	}
 	for {
-		_, ok, err := p.match(scanner.Xor)
-		switch {
-		case err != nil:
+		if _, ok, err := p.match(scanner.Ampersand); err != nil {
 			return nil, err
-		case ok:
-			right, err := p.andExpr()
-			if err != nil {
-				return nil, err
-			}
-			expr = &ast.BinaryExpr{expr, ast.Xor, right}
-		default:
-			return expr, nil
+		} else if !ok {
+			return left, nil
 		}
+		right, err := p.labelNegation()
+		if err != nil {
+			return nil, err
+		}
+		left = span(p, start, &ast.LabelAnd{Left: left, Right: right})
 	}
 }
 
-func (p *Parser) andExpr() (ast.Expr, error) {
-	expr, err := p.notExpr()
-	if err != nil {
+func (p *Parser) labelNegation() (ast.LabelExpr, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Exclamation); err != nil {
 		return nil, err
-	}
-	for {
-		_, ok, err := p.match(scanner.And)
-		switch {
-		case err != nil:
+	} else if ok {
+		expr, err := p.labelNegation()
+		if err != nil {
 			return nil, err
-		case ok:
-			right, err := p.notExpr()
-			if err != nil {
-				return nil, err
-			}
-			expr = &ast.BinaryExpr{expr, ast.And, right}
-		default:
-			return expr, nil
 		}
+		return span(p, start, &ast.LabelNot{Expr: expr}), nil
 	}
+	return p.labelPrimary()
 }
 
-func (p *Parser) notExpr() (ast.Expr, error) {
-	not := false
-	for {
-		_, ok, err := p.match(scanner.Not)
+func (p *Parser) labelPrimary() (ast.LabelExpr, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Percent); err != nil {
+		return nil, err
+	} else if ok {
+		return span(p, start, &ast.LabelWildcard{}), nil
+	}
+	if _, ok, err := p.match(scanner.OpenParen); err != nil {
+		return nil, err
+	} else if ok {
+		pipeDepth := p.pipeDepth
+		p.pipeDepth = 0
+		expr, err := p.labelExpression()
+		p.pipeDepth = pipeDepth
 		if err != nil {
 			return nil, err
-		} else if ok {
-			not = !not
-		} else {
-			break
 		}
+		if _, ok, err := p.match(scanner.CloseParen); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting ')' following label expression")
+		}
+		return expr, nil
 	}
-	expr, err := p.comparisonExpr()
+	name, err := p.schemaName()
 	if err != nil {
 		return nil, err
 	}
-	if not {
-		expr = &ast.UnaryExpr{ast.Not, expr}
+	if name == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting label expression")
 	}
-	return expr, nil
+	return span(p, start, &ast.LabelName{Name: name}), nil
 }
 
-func (p *Parser) comparisonExpr() (ast.Expr, error) {
-	tokenTypes := []scanner.TokenType{
-		scanner.Equal,
-		scanner.NotEqual,
-		scanner.LessThan,
-		scanner.GreaterThan,
-		scanner.LessThanOrEqual,
-		scanner.GreaterThanOrEqual,
+func (p *Parser) NodeLabels() ([]ast.SchemaName, error) {
+	labels := []ast.SchemaName{}
+	label, err := p.NodeLabel()
+	for err == nil && label != nil {
+		labels = append(labels, label)
+		label, err = p.NodeLabel()
 	}
-	expr, err := p.addOrSubtractExpr()
 	if err != nil {
 		return nil, err
 	}
-	for {
-		t, ok, err := p.match(tokenTypes...)
-		switch {
-		case err != nil:
+	return labels, nil
+}
+
+func (p *Parser) NodeLabel() (ast.SchemaName, error) {
+	if _, ok, err := p.match(scanner.Colon); err != nil {
+		return nil, err
+	} else if ok {
+		s, err := p.schemaName()
+		if err != nil {
 			return nil, err
-		case ok:
-			right, err := p.addOrSubtractExpr()
-			if err != nil {
-				return nil, err
-			}
-			op, _ := opForTokens[t.T]
-			expr = &ast.BinaryExpr{expr, op, right}
-		default:
-			return expr, nil
 		}
+		if s == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting schema name following ':'")
+		}
+		return s, nil
 	}
+	return nil, nil
 }
 
-func (p *Parser) addOrSubtractExpr() (ast.Expr, error) {
-	expr, err := p.multiplyDivideModuloExpr()
-	if err != nil {
+func (p *Parser) isNullExpr() (ast.Expr, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.match(scanner.Is); err != nil {
 		return nil, err
-	}
-	for {
-		t, ok, err := p.match(scanner.Plus, scanner.Dash)
-		switch {
-		case err != nil:
+	} else if ok {
+		if _, ok, err := p.match(scanner.Null); err != nil {
 			return nil, err
-		case ok:
-			right, err := p.multiplyDivideModuloExpr()
-			if err != nil {
+		} else if ok {
+			return span(p, start, &ast.OpExpr{Op: ast.IsNull}), nil
+		}
+		if _, ok, err := p.match(scanner.Not); err != nil {
+			return nil, err
+		} else if ok {
+			if _, ok, err := p.match(scanner.Null); err != nil {
 				return nil, err
+			} else if ok {
+				return span(p, start, &ast.OpExpr{Op: ast.IsNotNull}), nil
 			}
-			op, _ := opForTokens[t.T]
-			expr = &ast.BinaryExpr{expr, op, right}
-		default:
-			return expr, nil
 		}
 	}
+	p.scanner.Position = pos
+	return nil, nil
 }
 
-func (p *Parser) multiplyDivideModuloExpr() (ast.Expr, error) {
-	tokenTypes := []scanner.TokenType{
-		scanner.Star,
-		scanner.ForwardSlash,
-		scanner.Percent,
-	}
-	expr, err := p.powerExpr()
-	if err != nil {
+// typePredicateExpr parses the type predicate of expr IS [NOT] :: TYPE, which may also be written
+// IS [NOT] TYPED TYPE, or :: TYPE.
+func (p *Parser) typePredicateExpr() (ast.Expr, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	expr := &ast.TypePredicateExpr{}
+	if _, ok, err := p.match(scanner.DoubleColon); err != nil {
 		return nil, err
-	}
-	for {
-		t, ok, err := p.match(tokenTypes...)
-		switch {
-		case err != nil:
+	} else if !ok {
+		if _, ok, err := p.match(scanner.Is); err != nil {
 			return nil, err
-		case ok:
-			right, err := p.powerExpr()
-			if err != nil {
+		} else if !ok {
+			return nil, nil
+		}
+		if _, expr.Not, err = p.match(scanner.Not); err != nil {
+			return nil, err
+		}
+		if _, ok, err := p.match(scanner.DoubleColon); err != nil {
+			return nil, err
+		} else if !ok {
+			if _, ok, err := p.matchKeyword("TYPED"); err != nil {
 				return nil, err
+			} else if !ok {
+				p.scanner.Position = pos
+				return nil, nil
 			}
-			op, _ := opForTokens[t.T]
-			expr = &ast.BinaryExpr{expr, op, right}
-		default:
-			return expr, nil
 		}
 	}
+	var err error
+	if expr.Type, err = p.cypherType(); err != nil {
+		return nil, err
+	}
+	return span(p, start, expr), nil
 }
 
-func (p *Parser) powerExpr() (ast.Expr, error) {
-	expr, err := p.unaryAddOrSubtract()
+// cypherType parses a type, or a union of types separated by '|'.
+func (p *Parser) cypherType() (ast.CypherType, error) {
+	start := p.start()
+	t, err := p.nonUnionType()
 	if err != nil {
 		return nil, err
 	}
-	for {
-		t, ok, err := p.match(scanner.Caret)
-		switch {
-		case err != nil:
+	types := []ast.CypherType{t}
+	for p.pipeDepth == 0 {
+		if _, ok, err := p.match(scanner.Pipe); err != nil {
 			return nil, err
-		case ok:
-			right, err := p.unaryAddOrSubtract()
-			if err != nil {
-				return nil, err
-			}
-			expr = &ast.BinaryExpr{expr, ast.Operator(t.T), right}
-		default:
-			return expr, nil
+		} else if !ok {
+			break
 		}
-	}
-}
-
-func (p *Parser) unaryAddOrSubtract() (ast.Expr, error) {
-	tokenTypes := []scanner.TokenType{
-		scanner.Plus,
-		scanner.Dash,
-	}
-	negate := false
-	for {
-		t, ok, err := p.match(tokenTypes...)
+		t, err := p.nonUnionType()
 		if err != nil {
 			return nil, err
-		} else if ok {
-			if t.T == scanner.Dash {
-				negate = !negate
-			}
-		} else {
-			break
 		}
+		types = append(types, t)
 	}
-	expr, err := p.stringListNullOperatorExpr()
-	if err != nil {
-		return nil, err
-	}
-	if negate {
-		expr = &ast.UnaryExpr{ast.Negate, expr}
+	if len(types) == 1 {
+		return t, nil
 	}
-	return expr, nil
+	return span(p, start, &ast.UnionType{Types: types}), nil
 }
 
-func (p *Parser) stringListNullOperatorExpr() (ast.Expr, error) {
-	expr, err := p.propertyOrLabelsExpr()
+// nonUnionType parses a type with an optional NOT NULL, followed by any number of LIST or ARRAY suffixes,
+// as in INTEGER NOT NULL LIST.
+func (p *Parser) nonUnionType() (ast.CypherType, error) {
+	start := p.start()
+	t, err := p.basicType()
 	if err != nil {
 		return nil, err
 	}
-	list := []ast.Expr{}
 	for {
-		if expr, err := p.stringOpExpr(); err != nil {
+		if _, ok, err := p.matchPhrase(scanner.Not, scanner.Null); err != nil {
 			return nil, err
-		} else if expr != nil {
-			list = append(list, expr)
-			continue
+		} else if ok {
+			setNotNull(t)
+			t.(spanned).SetSpan(ast.Span{Start: start, End: p.scanner.Pos()})
 		}
-		if expr, err := p.listOpExpr(); err != nil {
+		if _, ok, err := p.matchKeyword("LIST"); err != nil {
 			return nil, err
-		} else if expr != nil {
-			list = append(list, expr)
+		} else if ok {
+			t = span(p, start, &ast.ListType{Element: t})
 			continue
 		}
-		if expr, err := p.isNullExpr(); err != nil {
+		if _, ok, err := p.matchKeyword("ARRAY"); err != nil {
 			return nil, err
-		} else if expr != nil {
-			list = append(list, expr)
+		} else if ok {
+			t = span(p, start, &ast.ListType{Element: t})
 			continue
 		}
-		break
+		return t, nil
 	}
-	if len(list) > 0 {
-		return &ast.BinaryExpr{expr, ast.StringOrListOp, &ast.ListExpr{list}}, nil
+}
+
+func setNotNull(t ast.CypherType) {
+	switch t := t.(type) {
+	case *ast.SimpleType:
+		t.NotNull = true
+	case *ast.ListType:
+		t.NotNull = true
+	case *ast.UnionType:
+		t.NotNull = true
 	}
-	return expr, nil
 }
 
-func (p *Parser) propertyOrLabelsExpr() (ast.Expr, error) {
-	atom, err := p.atom()
-	if err != nil {
+// typeNames are the types named by a single keyword.
+var typeNames = map[string]ast.ValueType{
+	"NOTHING":      ast.NothingValue,
+	"BOOL":         ast.BooleanValue,
+	"BOOLEAN":      ast.BooleanValue,
+	"VARCHAR":      ast.StringValue,
+	"STRING":       ast.StringValue,
+	"INT":          ast.IntegerValue,
+	"INTEGER":      ast.IntegerValue,
+	"FLOAT":        ast.FloatValue,
+	"DATE":         ast.DateValue,
+	"DURATION":     ast.DurationValue,
+	"POINT":        ast.PointValue,
+	"NODE":         ast.NodeValue,
+	"VERTEX":       ast.NodeValue,
+	"RELATIONSHIP": ast.RelationshipValue,
+	"EDGE":         ast.RelationshipValue,
+	"MAP":          ast.MapValue,
+	"PATH":         ast.PathValue,
+}
+
+func (p *Parser) basicType() (ast.CypherType, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Null); err != nil {
 		return nil, err
+	} else if ok {
+		return span(p, start, &ast.SimpleType{Type: ast.NullValue}), nil
 	}
-	properties := []ast.SchemaName{}
-	for {
-		property, err := p.propertyLookup()
-		if err != nil {
-			return nil, err
-		} else if property != nil {
-			properties = append(properties, property)
-		} else {
-			break
-		}
-	}
-	if len(properties) == 0 {
-		properties = nil
+	t, ok, err := p.match(scanner.Identifier)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting type")
+	}
+	keyword := strings.ToUpper(t.Lexeme)
+	if valueType, ok := typeNames[keyword]; ok {
+		return span(p, start, &ast.SimpleType{Type: valueType}), nil
+	}
+	switch keyword {
+	case "SIGNED":
+		return p.typeKeyword(start, "INTEGER", ast.IntegerValue)
+	case "LOCAL":
+		return p.temporalType(start, ast.LocalTimeValue, ast.LocalDateTimeValue)
+	case "ZONED":
+		return p.temporalType(start, ast.ZonedTimeValue, ast.ZonedDateTimeValue)
+	case "TIME":
+		return p.timeZoneType(start, ast.ZonedTimeValue, ast.LocalTimeValue)
+	case "TIMESTAMP":
+		return p.timeZoneType(start, ast.ZonedDateTimeValue, ast.LocalDateTimeValue)
+	case "PROPERTY":
+		return p.typeKeyword(start, "VALUE", ast.PropertyValue)
+	case "LIST", "ARRAY":
+		return p.listType(start)
+	case "ANY":
+		return p.anyType(start)
+	}
+	return nil, p.reporter.Error(t.Line, "expecting type")
+}
+
+// typeKeyword parses the keyword completing a type named by two keywords, such as PROPERTY VALUE.
+func (p *Parser) typeKeyword(start scanner.Pos, keyword string, valueType ast.ValueType) (ast.CypherType, error) {
+	if _, ok, err := p.matchKeyword(keyword); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '"+keyword+"'")
 	}
-	labels, _ := p.NodeLabels()
-	return &ast.PropertyLabelsExpr{atom, properties, labels}, nil
+	return span(p, start, &ast.SimpleType{Type: valueType}), nil
 }
 
-func (p *Parser) propertyLookup() (ast.SchemaName, error) {
-	if _, ok, err := p.match(scanner.Period); err != nil {
+// temporalType parses the TIME or DATETIME following LOCAL or ZONED.
+func (p *Parser) temporalType(start scanner.Pos, timeType ast.ValueType, dateTimeType ast.ValueType) (ast.CypherType, error) {
+	if _, ok, err := p.matchKeyword("TIME"); err != nil {
 		return nil, err
 	} else if ok {
-		sn, err := p.schemaName()
-		if err != nil {
-			return nil, err
-		}
-		if sn != nil {
-			return sn, nil
-		}
+		return span(p, start, &ast.SimpleType{Type: timeType}), nil
 	}
-	return nil, nil
+	return p.typeKeyword(start, "DATETIME", dateTimeType)
 }
 
-func (p *Parser) schemaName() (ast.SchemaName, error) {
-	pos := p.scanner.Position
-	t := p.scanner.NextToken()
-	if _, ok := scanner.ReservedWordTokens[t.T]; ok {
-		return &ast.ReservedWordSchemaName{t.T}, nil
+// timeZoneType parses the WITH TIME ZONE or WITHOUT TIME ZONE following TIME or TIMESTAMP. TIMEZONE may be
+// written as one word.
+func (p *Parser) timeZoneType(start scanner.Pos, zonedType ast.ValueType, localType ast.ValueType) (ast.CypherType, error) {
+	valueType := zonedType
+	if _, ok, err := p.match(scanner.With); err != nil {
+		return nil, err
+	} else if !ok {
+		if _, ok, err := p.matchKeyword("WITHOUT"); err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting 'WITH TIME ZONE' or 'WITHOUT TIME ZONE'")
+		}
+		valueType = localType
 	}
-	p.scanner.Position = pos
-	name, err := p.symbolicName()
-	if err != nil {
+	if _, ok, err := p.matchKeyword("TIMEZONE"); err != nil {
 		return nil, err
+	} else if ok {
+		return span(p, start, &ast.SimpleType{Type: valueType}), nil
 	}
-	if name != nil {
-		return &ast.SymbolicNameSchemaName{name}, nil
+	if _, ok, err := p.matchKeyword("TIME"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting 'TIME ZONE'")
 	}
-	return nil, nil
+	return p.typeKeyword(start, "ZONE", valueType)
 }
 
-func (p *Parser) NodeLabels() ([]ast.SchemaName, error) {
-	labels := []ast.SchemaName{}
-	label, err := p.NodeLabel()
-	for err == nil && label != nil {
-		labels = append(labels, label)
-		label, err = p.NodeLabel()
+// listType parses the <type> following LIST or ARRAY.
+func (p *Parser) listType(start scanner.Pos) (ast.CypherType, error) {
+	if _, ok, err := p.match(scanner.LessThan); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '<' following list type")
 	}
+	element, err := p.nestedType()
 	if err != nil {
 		return nil, err
 	}
-	return labels, nil
+	return span(p, start, &ast.ListType{Element: element}), nil
 }
 
-func (p *Parser) NodeLabel() (ast.SchemaName, error) {
-	if _, ok, err := p.match(scanner.Colon); err != nil {
+// anyType parses what follows ANY: NODE, RELATIONSHIP, MAP, PROPERTY VALUE, VALUE, <type>, or nothing at all.
+func (p *Parser) anyType(start scanner.Pos) (ast.CypherType, error) {
+	if _, ok, err := p.match(scanner.LessThan); err != nil {
 		return nil, err
 	} else if ok {
-		s, err := p.schemaName()
+		t, err := p.nestedType()
 		if err != nil {
 			return nil, err
 		}
-		if s == nil {
-			return nil, p.reporter.Error(p.scanner.Line(), "expecting schema name following ':'")
+		if union, ok := t.(*ast.UnionType); ok {
+			return span(p, start, union), nil
 		}
-		return s, nil
+		return span(p, start, &ast.UnionType{Types: []ast.CypherType{t}}), nil
 	}
-	return nil, nil
-}
-
-func (p *Parser) isNullExpr() (ast.Expr, error) {
-	if _, ok, err := p.match(scanner.Is); err != nil {
+	pos := p.scanner.Position
+	if t, ok, err := p.match(scanner.Identifier); err != nil {
 		return nil, err
 	} else if ok {
-		if _, ok, err := p.match(scanner.Null); err != nil {
-			return nil, err
-		} else if ok {
-			return &ast.OpExpr{ast.IsNull}, nil
-		}
-		if _, ok, err := p.match(scanner.Not); err != nil {
-			return nil, err
-		} else if ok {
-			if _, ok, err := p.match(scanner.Null); err != nil {
-				return nil, err
-			} else if ok {
-				return &ast.OpExpr{ast.IsNotNull}, nil
-			}
+		switch strings.ToUpper(t.Lexeme) {
+		case "NODE", "VERTEX":
+			return span(p, start, &ast.SimpleType{Type: ast.NodeValue}), nil
+		case "RELATIONSHIP", "EDGE":
+			return span(p, start, &ast.SimpleType{Type: ast.RelationshipValue}), nil
+		case "MAP":
+			return span(p, start, &ast.SimpleType{Type: ast.MapValue}), nil
+		case "VALUE":
+			return span(p, start, &ast.SimpleType{Type: ast.AnyValue}), nil
+		case "PROPERTY":
+			return p.typeKeyword(start, "VALUE", ast.PropertyValue)
 		}
 	}
-	return nil, nil
+	p.scanner.Position = pos
+	return span(p, start, &ast.SimpleType{Type: ast.AnyValue}), nil
+}
+
+// nestedType parses the type and closing '>' of LIST<type> or ANY<type>. The angle brackets delimit the
+// type, so a '|' within it is always a union.
+func (p *Parser) nestedType() (ast.CypherType, error) {
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	t, err := p.cypherType()
+	p.pipeDepth = pipeDepth
+	if err != nil {
+		return nil, err
+	}
+	if _, ok, err := p.match(scanner.GreaterThan); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '>' following type")
+	}
+	return t, nil
 }
 
 func (p *Parser) stringOpExpr() (ast.Expr, error) {
+	start := p.start()
 	if t, ok, err := p.match(scanner.Starts, scanner.Ends); err != nil {
 		return nil, err
 	} else if ok {
@@ -806,9 +2722,9 @@ func (p *Parser) stringOpExpr() (ast.Expr, error) {
 			}
 			switch t.T {
 			case scanner.Starts:
-				return &ast.UnaryExpr{ast.StartsWith, expr}, nil
+				return span(p, start, &ast.UnaryExpr{Op: ast.StartsWith, Expr: expr}), nil
 			case scanner.Ends:
-				return &ast.UnaryExpr{ast.EndsWith, expr}, nil
+				return span(p, start, &ast.UnaryExpr{Op: ast.EndsWith, Expr: expr}), nil
 			}
 		}
 		p.reporter.Error(t.Line, "expecting WITH")
@@ -820,12 +2736,13 @@ func (p *Parser) stringOpExpr() (ast.Expr, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &ast.UnaryExpr{ast.Contains, expr}, nil
+		return span(p, start, &ast.UnaryExpr{Op: ast.Contains, Expr: expr}), nil
 	}
 	return nil, nil
 }
 
 func (p *Parser) listOpExpr() (ast.Expr, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.In); err != nil {
 		return nil, err
 	} else if ok {
@@ -833,7 +2750,7 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 		if err != nil {
 			return nil, err
 		}
-		return &ast.ListOperatorExpr{Op: ast.InList, Expr: list}, nil
+		return span(p, start, &ast.ListOperatorExpr{Op: ast.InList, Expr: list}), nil
 	}
 
 	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
@@ -852,7 +2769,7 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 		if _, ok, err := p.match(scanner.CloseBracket); err != nil {
 			return nil, err
 		} else if ok {
-			return &ast.ListOperatorExpr{Op: ast.ListRange}, nil
+			return span(p, start, &ast.ListOperatorExpr{Op: ast.ListRange}), nil
 		}
 		endExpr, err := p.expr()
 		if err != nil {
@@ -861,7 +2778,7 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 		if _, ok, err := p.match(scanner.CloseBracket); err != nil {
 			return nil, err
 		} else if ok {
-			return &ast.ListOperatorExpr{Op: ast.ListRange, EndExpr: endExpr}, nil
+			return span(p, start, &ast.ListOperatorExpr{Op: ast.ListRange, EndExpr: endExpr}), nil
 		}
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting ']' to close a list operator")
 	}
@@ -874,7 +2791,7 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 		return nil, err
 	} else if ok {
 		// Found ']' so this is a list index operation as opposed to a slice operation.
-		return &ast.ListOperatorExpr{Op: ast.ListIndex, Expr: expr}, nil
+		return span(p, start, &ast.ListOperatorExpr{Op: ast.ListIndex, Expr: expr}), nil
 	}
 
 	if _, ok, err := p.match(scanner.Dotdot); err != nil {
@@ -888,7 +2805,7 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 	if _, ok, err := p.match(scanner.CloseBracket); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.ListOperatorExpr{Op: ast.ListRange, Expr: expr}, nil
+		return span(p, start, &ast.ListOperatorExpr{Op: ast.ListRange, Expr: expr}), nil
 	}
 	endExpr, err := p.expr()
 	if err != nil {
@@ -897,13 +2814,14 @@ func (p *Parser) listOpExpr() (ast.Expr, error) {
 	if _, ok, err := p.match(scanner.CloseBracket); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.ListOperatorExpr{Op: ast.ListRange, Expr: expr, EndExpr: endExpr}, nil
+		return span(p, start, &ast.ListOperatorExpr{Op: ast.ListRange, Expr: expr, EndExpr: endExpr}), nil
 	}
 	return nil, p.reporter.Error(p.scanner.Line(), "expecting ']' to close a list operator")
 }
 
 func (p *Parser) atom() (ast.Expr, error) {
 	pos := p.scanner.Position
+	start := p.start()
 	if expr, _ := p.patternComprehensionExpr(); expr != nil {
 		return expr, nil
 	}
@@ -922,10 +2840,20 @@ func (p *Parser) atom() (ast.Expr, error) {
 	} else if expr != nil {
 		return expr, nil
 	}
+	if expr, err := p.existsSubqueryExpr(); err != nil {
+		return nil, err
+	} else if expr != nil {
+		return expr, nil
+	}
+	if expr, err := p.countSubqueryExpr(); err != nil {
+		return nil, err
+	} else if expr != nil {
+		return expr, nil
+	}
 	if _, ok, err := p.matchPhrase(scanner.Identifier, scanner.OpenParen, scanner.Star, scanner.CloseParen); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.OpExpr{ast.CountAll}, nil
+		return span(p, start, &ast.OpExpr{Op: ast.CountAll}), nil
 	}
 	if expr, err := p.listComprehensionExpr(); err != nil {
 		return nil, err
@@ -946,6 +2874,11 @@ func (p *Parser) atom() (ast.Expr, error) {
 	} else if expr != nil {
 		return expr, nil
 	}
+	if expr, err := p.shortestPathPattern(); err != nil {
+		return nil, err
+	} else if expr != nil {
+		return expr, nil
+	}
 	if expr, err := p.functionInvocation(); err != nil {
 		return nil, err
 	} else if expr != nil {
@@ -955,26 +2888,32 @@ func (p *Parser) atom() (ast.Expr, error) {
 	if symbolicName, err := p.variable(); err != nil {
 		return nil, err
 	} else if symbolicName != nil {
-		return &ast.VariableExpr{symbolicName}, nil
+		if expr, err := p.mapProjection(start, symbolicName); err != nil {
+			return nil, err
+		} else if expr != nil {
+			return expr, nil
+		}
+		return span(p, start, &ast.VariableExpr{SymbolicName: symbolicName}), nil
 	}
 	return nil, p.reporter.Error(p.scanner.Line(), "expecting atom")
 }
 
 func (p *Parser) literal() (ast.Expr, error) {
+	start := p.start()
 	if t, ok, err := p.match(scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger, scanner.Double, scanner.String, scanner.Null, scanner.False, scanner.True); err != nil {
 		return nil, err
 	} else if ok {
 		switch t.T {
 		case scanner.DecimalInteger, scanner.HexInteger, scanner.OctInteger:
-			return &ast.PrimitiveLiteral{scanner.Integer, t.Literal}, nil
+			return span(p, start, &ast.PrimitiveLiteral{Kind: scanner.Integer, Value: t.Literal}), nil
 		case scanner.Double, scanner.String:
-			return &ast.PrimitiveLiteral{t.T, t.Literal}, nil
+			return span(p, start, &ast.PrimitiveLiteral{Kind: t.T, Value: t.Literal}), nil
 		case scanner.False:
-			return &ast.PrimitiveLiteral{t.T, false}, nil
+			return span(p, start, &ast.PrimitiveLiteral{Kind: t.T, Value: false}), nil
 		case scanner.True:
-			return &ast.PrimitiveLiteral{t.T, true}, nil
+			return span(p, start, &ast.PrimitiveLiteral{Kind: t.T, Value: true}), nil
 		case scanner.Null:
-			return &ast.PrimitiveLiteral{Kind: t.T}, nil
+			return span(p, start, &ast.PrimitiveLiteral{Kind: t.T}), nil
 		}
 	}
 	if expr, err := p.mapLiteral(); err != nil {
@@ -991,6 +2930,7 @@ func (p *Parser) literal() (ast.Expr, error) {
 }
 
 func (p *Parser) parameter() (ast.Expr, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.DollarSign); err != nil {
 		return nil, err
 	} else if ok {
@@ -999,12 +2939,12 @@ func (p *Parser) parameter() (ast.Expr, error) {
 			return nil, err
 		}
 		if s != nil {
-			return &ast.Parameter{SymbolicName: s}, nil
+			return span(p, start, &ast.Parameter{SymbolicName: s}), nil
 		}
 		if t, ok, err := p.match(scanner.DecimalInteger); err != nil {
 			return nil, err
 		} else if ok {
-			return &ast.Parameter{N: &t}, nil
+			return span(p, start, &ast.Parameter{N: &t}), nil
 		} else {
 			return nil, p.reporter.Error(t.Line, "expecting symbolic name or integer")
 		}
@@ -1013,6 +2953,7 @@ func (p *Parser) parameter() (ast.Expr, error) {
 }
 
 func (p *Parser) caseExpr() (ast.Expr, error) {
+	start := p.start()
 	if t, ok, err := p.match(scanner.Case); err != nil {
 		return nil, err
 	} else if ok {
@@ -1053,7 +2994,7 @@ func (p *Parser) caseExpr() (ast.Expr, error) {
 		if t, ok, err := p.match(scanner.End); err != nil {
 			return nil, err
 		} else if ok {
-			return &ast.CaseExpr{Init: initExpr, Alternatives: caseAlts, Else: elseExpr}, nil
+			return span(p, start, &ast.CaseExpr{Init: initExpr, Alternatives: caseAlts, Else: elseExpr}), nil
 		} else {
 			return nil, p.reporter.Error(t.Line, "expecting CASE END")
 		}
@@ -1062,6 +3003,7 @@ func (p *Parser) caseExpr() (ast.Expr, error) {
 }
 
 func (p *Parser) caseAlt() (*ast.CaseAltNode, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.When); err != nil {
 		return nil, err
 	} else if ok {
@@ -1076,7 +3018,7 @@ func (p *Parser) caseAlt() (*ast.CaseAltNode, error) {
 			if err != nil {
 				return nil, err
 			}
-			return &ast.CaseAltNode{whenExpr, thenExpr}, nil
+			return span(p, start, &ast.CaseAltNode{When: whenExpr, Then: thenExpr}), nil
 		} else {
 			return nil, p.reporter.Error(t.Line, "expecting symbolic name or integer")
 		}
@@ -1085,6 +3027,7 @@ func (p *Parser) caseAlt() (*ast.CaseAltNode, error) {
 }
 
 func (p *Parser) listComprehensionExpr() (ast.Expr, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
 		return nil, err
 	} else if !ok {
@@ -1092,7 +3035,9 @@ func (p *Parser) listComprehensionExpr() (ast.Expr, error) {
 	}
 	var err error
 	listCompExpr := &ast.ListComprehensionExpr{}
+	p.pipeDepth++
 	listCompExpr.FilterExpr, err = p.filterExpr()
+	p.pipeDepth--
 	if err != nil {
 		return nil, err
 	}
@@ -1113,10 +3058,11 @@ func (p *Parser) listComprehensionExpr() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ']'")
 	}
-	return listCompExpr, nil
+	return span(p, start, listCompExpr), nil
 }
 
 func (p *Parser) filterExpr() (ast.Expr, error) {
+	start := p.start()
 	filterExpr := &ast.FilterExpr{}
 	var err error
 	filterExpr.Variable, err = p.variable()
@@ -1149,7 +3095,7 @@ func (p *Parser) filterExpr() (ast.Expr, error) {
 			return nil, p.reporter.Error(p.scanner.Line(), "expecting 'WHERE' expression")
 		}
 	}
-	return filterExpr, nil
+	return span(p, start, filterExpr), nil
 }
 
 var quantifierNames = map[string]ast.Operator{
@@ -1161,6 +3107,7 @@ var quantifierNames = map[string]ast.Operator{
 
 func (p *Parser) quantifierFunction() (ast.Expr, error) {
 	pos := p.scanner.Position
+	start := p.start()
 	// 'ALL' is a keyword, the other three are not, so they are handled separately. The following code figures
 	// out the quantifier operation being invoked.
 	var op ast.Operator
@@ -1198,7 +3145,7 @@ func (p *Parser) quantifierFunction() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting ')'")
 	}
-	return &ast.QuantifierExpr{op, expr}, nil
+	return span(p, start, &ast.QuantifierExpr{Op: op, Expr: expr}), nil
 }
 
 func (p *Parser) variable() (ast.SymbolicName, error) {
@@ -1213,18 +3160,20 @@ func (p *Parser) variable() (ast.SymbolicName, error) {
 }
 
 func (p *Parser) symbolicName() (ast.SymbolicName, error) {
+	start := p.start()
 	if t, ok, err := p.match(scanner.Identifier); err != nil {
 		return nil, err
 	} else if ok {
 		if symbolType, ok := ast.SymbolNames[t.Lexeme]; ok {
-			return &ast.SymbolicNameIdentifier{t, symbolType}, nil
+			return span(p, start, &ast.SymbolicNameIdentifier{Identifier: t, Type: symbolType}), nil
 		}
-		return &ast.SymbolicNameIdentifier{t, ast.Identifier}, nil
+		return span(p, start, &ast.SymbolicNameIdentifier{Identifier: t, Type: ast.Identifier}), nil
 	}
 	return nil, nil
 }
 
 func (p *Parser) patternComprehensionExpr() (ast.Expr, error) {
+	start := p.start()
 	var err error
 	patternExpr := &ast.PatternComprehensionExpr{}
 	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
@@ -1250,7 +3199,9 @@ func (p *Parser) patternComprehensionExpr() (ast.Expr, error) {
 	if _, ok, err := p.match(scanner.Where); err != nil {
 		return nil, err
 	} else if ok {
+		p.pipeDepth++
 		patternExpr.WhereExpr, err = p.expr()
+		p.pipeDepth--
 		if err != nil {
 			return nil, err
 		}
@@ -1275,10 +3226,11 @@ func (p *Parser) patternComprehensionExpr() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ']'")
 	}
-	return patternExpr, nil
+	return span(p, start, patternExpr), nil
 }
 
 func (p *Parser) relationshipsPattern() (*ast.RelationshipsPattern, error) {
+	start := p.start()
 	var err error
 	rel := &ast.RelationshipsPattern{
 		Chain: []*ast.PatternElementChain{},
@@ -1308,10 +3260,11 @@ func (p *Parser) relationshipsPattern() (*ast.RelationshipsPattern, error) {
 		}
 		rel.Chain = append(rel.Chain, chain)
 	}
-	return rel, nil
+	return span(p, start, rel), nil
 }
 
 func (p *Parser) patternElementChain() (*ast.PatternElementChain, error) {
+	start := p.start()
 	var err error
 	chain := &ast.PatternElementChain{}
 	chain.RelationshipPattern, err = p.relationshipPattern()
@@ -1328,10 +3281,11 @@ func (p *Parser) patternElementChain() (*ast.PatternElementChain, error) {
 	if chain.Right == nil {
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting node pattern")
 	}
-	return chain, nil
+	return span(p, start, chain), nil
 }
 
 func (p *Parser) relationshipPattern() (*ast.RelationshipPattern, error) {
+	start := p.start()
 	var err error
 	pattern := &ast.RelationshipPattern{Left: ast.Undirected, Right: ast.Undirected}
 	pos := p.scanner.Position
@@ -1361,10 +3315,11 @@ func (p *Parser) relationshipPattern() (*ast.RelationshipPattern, error) {
 	} else if ok {
 		pattern.Right = ast.Directed
 	}
-	return pattern, nil
+	return span(p, start, pattern), nil
 }
 
 func (p *Parser) relationshipDetail() (*ast.RelationshipDetail, error) {
+	start := p.start()
 	var err error
 	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
 		return nil, err
@@ -1376,7 +3331,7 @@ func (p *Parser) relationshipDetail() (*ast.RelationshipDetail, error) {
 	if err != nil {
 		return nil, err
 	}
-	detail.RelationshipTypes, err = p.relationshipTypes()
+	detail.RelationshipTypes, detail.LabelExpr, err = p.relationshipTypes()
 	if err != nil {
 		return nil, err
 	}
@@ -1388,15 +3343,20 @@ func (p *Parser) relationshipDetail() (*ast.RelationshipDetail, error) {
 	if err != nil {
 		return nil, err
 	}
+	detail.WhereExpr, err = p.patternPredicate()
+	if err != nil {
+		return nil, err
+	}
 	if t, ok, err := p.match(scanner.CloseBracket); err != nil {
 		return nil, err
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ']'")
 	}
-	return detail, nil
+	return span(p, start, detail), nil
 }
 
 func (p *Parser) properties() (*ast.Properties, error) {
+	start := p.start()
 	var err error
 	properties := &ast.Properties{}
 	expr, err := p.mapLiteral()
@@ -1405,19 +3365,20 @@ func (p *Parser) properties() (*ast.Properties, error) {
 	}
 	if expr != nil {
 		properties.MapLiteral = expr.(*ast.MapLiteral)
-		return properties, nil
+		return span(p, start, properties), nil
 	}
 	properties.Parameter, err = p.parameter()
 	if err != nil {
 		return nil, err
 	}
 	if properties.Parameter != nil {
-		return properties, nil
+		return span(p, start, properties), nil
 	}
 	return nil, nil
 }
 
 func (p *Parser) rangeLiteral() (*ast.RangeLiteral, error) {
+	start := p.start()
 	literal := &ast.RangeLiteral{Begin: math.MinInt64, End: math.MaxInt64}
 	if _, ok, err := p.match(scanner.Star); err != nil {
 		return nil, err
@@ -1438,46 +3399,48 @@ func (p *Parser) rangeLiteral() (*ast.RangeLiteral, error) {
 			literal.End = t.Literal.(int64)
 		}
 	}
-	return literal, nil
+	return span(p, start, literal), nil
 }
 
-func (p *Parser) relationshipTypes() ([]ast.SchemaName, error) {
+// relationshipTypes parses the types of a relationship pattern. A disjunction of names, :A|B, is returned as a
+// list of types, any other label expression is returned as a LabelExpr.
+func (p *Parser) relationshipTypes() ([]ast.SchemaName, ast.LabelExpr, error) {
 	if _, ok, err := p.match(scanner.Colon); err != nil {
-		return nil, err
+		return nil, nil, err
 	} else if !ok {
-		return nil, nil
+		return nil, nil, nil
 	}
-	typeNames := []ast.SchemaName{}
-	s, err := p.schemaName()
+	expr, err := p.labelExpression()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if s == nil {
-		return nil, p.reporter.Error(p.scanner.Line(), "expecting relationship type name")
+	if typeNames, ok := labelDisjunction(expr); ok {
+		return typeNames, nil, nil
 	}
-	typeNames = append(typeNames, s)
-	for {
-		if _, ok, err := p.match(scanner.Pipe); err != nil {
-			return nil, err
-		} else if !ok {
-			return typeNames, nil
-		}
-		_, _, err := p.match(scanner.Colon)
-		if err != nil {
-			return nil, err
-		}
-		s, err := p.schemaName()
-		if err != nil {
-			return nil, err
+	return nil, expr, nil
+}
+
+// labelDisjunction returns the names of a label expression made only of names and '|'.
+func labelDisjunction(expr ast.LabelExpr) ([]ast.SchemaName, bool) {
+	switch e := expr.(type) {
+	case *ast.LabelName:
+		return []ast.SchemaName{e.Name}, true
+	case *ast.LabelOr:
+		left, ok := labelDisjunction(e.Left)
+		if !ok {
+			return nil, false
 		}
-		if s == nil {
-			return nil, p.reporter.Error(p.scanner.Line(), "expecting relationship type name")
+		right, ok := labelDisjunction(e.Right)
+		if !ok {
+			return nil, false
 		}
-		typeNames = append(typeNames, s)
+		return append(left, right...), true
 	}
+	return nil, false
 }
 
 func (p *Parser) nodePattern() (*ast.NodePattern, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.OpenParen); err != nil {
 		return nil, err
 	} else if !ok {
@@ -1489,10 +3452,11 @@ func (p *Parser) nodePattern() (*ast.NodePattern, error) {
 	if err != nil {
 		return nil, err
 	}
-	np.Labels, err = p.NodeLabels()
+	np.Labels, np.LabelExpr, err = p.labels()
 	if err != nil {
 		return nil, err
 	}
+	propertiesStart := p.start()
 	expr, err := p.mapLiteral()
 	if err != nil {
 		return nil, err
@@ -1503,20 +3467,47 @@ func (p *Parser) nodePattern() (*ast.NodePattern, error) {
 			return nil, err
 		}
 		if parameter != nil {
-			np.Properties = &ast.Properties{Parameter: parameter}
+			np.Properties = span(p, propertiesStart, &ast.Properties{Parameter: parameter})
 		}
 	} else {
-		np.Properties = &ast.Properties{MapLiteral: expr.(*ast.MapLiteral)}
+		np.Properties = span(p, propertiesStart, &ast.Properties{MapLiteral: expr.(*ast.MapLiteral)})
+	}
+	np.WhereExpr, err = p.patternPredicate()
+	if err != nil {
+		return nil, err
 	}
 	if t, ok, err := p.match(scanner.CloseParen); err != nil {
 		return nil, err
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ')' following node pattern")
 	}
-	return np, nil
+	return span(p, start, np), nil
+}
+
+// patternPredicate parses the optional inline WHERE predicate of a node or relationship pattern.
+func (p *Parser) patternPredicate() (ast.Expr, error) {
+	if _, ok, err := p.match(scanner.Where); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+
+	// The pattern's brackets delimit the predicate, so a '|' within it never ends an enclosing expression.
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	expr, err := p.expr()
+	p.pipeDepth = pipeDepth
+	if err != nil {
+		return nil, err
+	}
+	if expr == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting expression following 'WHERE'")
+	}
+	return expr, nil
 }
 
 func (p *Parser) mapLiteral() (ast.Expr, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.OpenBrace); err != nil {
 		return nil, err
 	} else if !ok {
@@ -1525,6 +3516,7 @@ func (p *Parser) mapLiteral() (ast.Expr, error) {
 	literal := &ast.MapLiteral{PropertyKeyNames: []*ast.PropertyKeyName{}}
 	for {
 		var err error
+		keyStart := p.start()
 		pkn := &ast.PropertyKeyName{}
 		pkn.Name, err = p.schemaName()
 		if err != nil {
@@ -1545,7 +3537,7 @@ func (p *Parser) mapLiteral() (ast.Expr, error) {
 		if pkn.Expr == nil {
 			return nil, p.reporter.Error(p.scanner.Line(), "expecting expression following ':'")
 		}
-		literal.PropertyKeyNames = append(literal.PropertyKeyNames, pkn)
+		literal.PropertyKeyNames = append(literal.PropertyKeyNames, span(p, keyStart, pkn))
 		if _, ok, err := p.match(scanner.Comma); err != nil {
 			return nil, err
 		} else if !ok {
@@ -1557,7 +3549,91 @@ func (p *Parser) mapLiteral() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting '}' following map literal")
 	}
-	return literal, nil
+	return span(p, start, literal), nil
+}
+
+// mapProjection parses the selectors of a map projection following its variable.
+func (p *Parser) mapProjection(start scanner.Pos, variable ast.SymbolicName) (ast.Expr, error) {
+	if _, ok, err := p.match(scanner.OpenBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	projection := &ast.MapProjectionExpr{Variable: variable, Selectors: []ast.MapProjectionSelector{}}
+	if _, ok, err := p.match(scanner.CloseBrace); err != nil {
+		return nil, err
+	} else if ok {
+		return span(p, start, projection), nil
+	}
+	for {
+		selector, err := p.mapProjectionSelector()
+		if err != nil {
+			return nil, err
+		}
+		projection.Selectors = append(projection.Selectors, selector)
+		if _, ok, err := p.match(scanner.Comma); err != nil {
+			return nil, err
+		} else if !ok {
+			break
+		}
+	}
+	if _, ok, err := p.match(scanner.CloseBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting '}' following map projection")
+	}
+	return span(p, start, projection), nil
+}
+
+func (p *Parser) mapProjectionSelector() (ast.MapProjectionSelector, error) {
+	start := p.start()
+	if _, ok, err := p.match(scanner.Period); err != nil {
+		return nil, err
+	} else if ok {
+		if _, ok, err := p.match(scanner.Star); err != nil {
+			return nil, err
+		} else if ok {
+			return span(p, start, &ast.AllPropertiesSelector{}), nil
+		}
+		property, err := p.schemaName()
+		if err != nil {
+			return nil, err
+		}
+		if property == nil {
+			return nil, p.reporter.Error(p.scanner.Line(), "expecting property key name or '*' following '.'")
+		}
+		return span(p, start, &ast.PropertySelector{Property: property}), nil
+	}
+
+	// A key followed by ':' is a literal entry, otherwise the selector is a variable.
+	pos := p.scanner.Position
+	key, err := p.schemaName()
+	if err != nil {
+		return nil, err
+	}
+	if key != nil {
+		if _, ok, err := p.match(scanner.Colon); err != nil {
+			return nil, err
+		} else if ok {
+			expr, err := p.expr()
+			if err != nil {
+				return nil, err
+			}
+			if expr == nil {
+				return nil, p.reporter.Error(p.scanner.Line(), "expecting expression following ':'")
+			}
+			return span(p, start, &ast.LiteralEntrySelector{Key: key, Expr: expr}), nil
+		}
+	}
+	p.scanner.Position = pos
+	variable, err := p.variable()
+	if err != nil {
+		return nil, err
+	}
+	if variable == nil {
+		return nil, p.reporter.Error(p.scanner.Line(), "expecting map projection selector")
+	}
+	return span(p, start, &ast.VariableSelector{Variable: variable}), nil
 }
 
 func (p *Parser) parenthesizedExpr() (ast.Expr, error) {
@@ -1578,7 +3654,85 @@ func (p *Parser) parenthesizedExpr() (ast.Expr, error) {
 	return expr, nil
 }
 
+func (p *Parser) existsSubqueryExpr() (ast.Expr, error) {
+	start := p.start()
+	if _, ok, err := p.matchPhrase(scanner.Exists, scanner.OpenBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	pattern, where, query, err := p.subquery()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.ExistsSubqueryExpr{Pattern: pattern, WhereExpr: where, Query: query}), nil
+}
+
+func (p *Parser) countSubqueryExpr() (ast.Expr, error) {
+	pos := p.scanner.Position
+	start := p.start()
+	if _, ok, err := p.matchKeyword("COUNT"); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, nil
+	}
+	if _, ok, err := p.match(scanner.OpenBrace); err != nil {
+		return nil, err
+	} else if !ok {
+		p.scanner.Position = pos
+		return nil, nil
+	}
+	pattern, where, query, err := p.subquery()
+	if err != nil {
+		return nil, err
+	}
+	return span(p, start, &ast.CountSubqueryExpr{Pattern: pattern, WhereExpr: where, Query: query}), nil
+}
+
+// subquery parses the body of a subquery expression following the '{', up to and including the closing '}'.
+// The body is either a pattern with an optional WHERE expression, or a query. Every clause starts with a
+// keyword, so a body starting with '(' or with an identifier other than CALL is a pattern.
+func (p *Parser) subquery() (*ast.Pattern, ast.Expr, ast.Query, error) {
+	var pattern *ast.Pattern
+	var where ast.Expr
+	var query ast.Query
+	var err error
+
+	// The braces delimit the body, so a '|' within it never ends an enclosing expression.
+	pipeDepth := p.pipeDepth
+	p.pipeDepth = 0
+	defer func() { p.pipeDepth = pipeDepth }()
+
+	t := p.peek()
+	if t.T == scanner.OpenParen || (t.T == scanner.Identifier && strings.ToUpper(t.Lexeme) != "CALL") {
+		if pattern, err = p.pattern(); err != nil {
+			return nil, nil, nil, err
+		}
+		if _, ok, err := p.match(scanner.Where); err != nil {
+			return nil, nil, nil, err
+		} else if ok {
+			if where, err = p.expr(); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	} else {
+		p.subqueryDepth++
+		query, err = p.regularQuery()
+		p.subqueryDepth--
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if _, ok, err := p.match(scanner.CloseBrace); err != nil {
+		return nil, nil, nil, err
+	} else if !ok {
+		return nil, nil, nil, p.reporter.Error(p.scanner.Line(), "expecting '}' following subquery")
+	}
+	return pattern, where, query, nil
+}
+
 func (p *Parser) functionInvocation() (ast.Expr, error) {
+	start := p.start()
 	fn, err := p.functionName()
 	if err != nil {
 		return nil, err
@@ -1598,7 +3752,7 @@ func (p *Parser) functionInvocation() (ast.Expr, error) {
 	if _, ok, err := p.match(scanner.CloseParen); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.FunctionInvocation{FunctionName: fn, Distinct: distinct}, nil
+		return span(p, start, &ast.FunctionInvocation{FunctionName: fn, Distinct: distinct}), nil
 	}
 
 	// The following code expects at least one argument.
@@ -1622,6 +3776,7 @@ func (p *Parser) functionInvocation() (ast.Expr, error) {
 			if expr == nil {
 				return nil, p.reporter.Error(p.scanner.Line(), "expecting argument following ','")
 			}
+			args = append(args, expr)
 		}
 	}
 	if t, ok, err := p.match(scanner.CloseParen); err != nil {
@@ -1629,14 +3784,15 @@ func (p *Parser) functionInvocation() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ')' function parameters")
 	}
-	return &ast.FunctionInvocation{FunctionName: fn, Distinct: distinct, Args: args}, nil
+	return span(p, start, &ast.FunctionInvocation{FunctionName: fn, Distinct: distinct, Args: args}), nil
 }
 
 func (p *Parser) functionName() (ast.FunctionName, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.Exists); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.ExistsFunctionName{}, nil
+		return span(p, start, &ast.ExistsFunctionName{}), nil
 	}
 	ns, err := p.namespace()
 	if err != nil {
@@ -1652,7 +3808,7 @@ func (p *Parser) functionName() (ast.FunctionName, error) {
 		}
 		return nil, p.reporter.Error(p.scanner.Line(), "expecting function name")
 	}
-	return &ast.SymbolicFunctionName{Namespace: ns, FunctionName: name}, nil
+	return span(p, start, &ast.SymbolicFunctionName{Namespace: ns, FunctionName: name}), nil
 }
 
 func (p *Parser) namespace() ([]ast.SymbolicName, error) {
@@ -1679,6 +3835,7 @@ func (p *Parser) namespace() ([]ast.SymbolicName, error) {
 }
 
 func (p *Parser) listLiteral() (ast.Expr, error) {
+	start := p.start()
 	if _, ok, err := p.match(scanner.OpenBracket); err != nil {
 		return nil, err
 	} else if !ok {
@@ -1692,7 +3849,7 @@ func (p *Parser) listLiteral() (ast.Expr, error) {
 	if _, ok, err := p.match(scanner.CloseBracket); err != nil {
 		return nil, err
 	} else if ok {
-		return &ast.ListLiteral{Items: items}, nil
+		return span(p, start, &ast.ListLiteral{Items: items}), nil
 	}
 
 	expr, err := p.expr()
@@ -1719,5 +3876,5 @@ func (p *Parser) listLiteral() (ast.Expr, error) {
 	} else if !ok {
 		return nil, p.reporter.Error(t.Line, "expecting ']' to close a list")
 	}
-	return &ast.ListLiteral{Items: items}, nil
+	return span(p, start, &ast.ListLiteral{Items: items}), nil
 }
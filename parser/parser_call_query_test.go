@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/call
+
+// call/Call1.feature
+func TestStandaloneCall(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"CALL test.doNothing()":                     {"CALL test.doNothing()", true},
+		"CALL test.doNothing":                       {"CALL test.doNothing", true},
+		"CALL test.my.proc('Dog', 'Cat')":           {"CALL test.my.proc('Dog', 'Cat')", true},
+		"CALL test.my.proc":                         {"CALL test.my.proc", true},
+		"call test.labels() yield label":            {"call test.labels() yield label", true},
+		"CALL test.labels() YIELD *":                {"CALL test.labels() YIELD *", true},
+		"CALL test.my.proc(null) YIELD out":         {"CALL test.my.proc(null) YIELD out", true},
+		"CALL test.my.proc($input) YIELD a AS b, c": {"CALL test.my.proc($input) YIELD a AS b, c", true},
+		"CALL test.doNothing(":                      {"CALL test.doNothing(", false},
+		"CALL test.my.proc('Dog',)":                 {"CALL test.my.proc('Dog',)", false},
+		"CALL":                                      {"CALL", false},
+		"CALL test.labels() YIELD":                  {"CALL test.labels() YIELD", false},
+		"CALL test.labels() YIELD label AS":         {"CALL test.labels() YIELD label AS", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+// call/Call2.feature
+func TestInQueryCall(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n) CALL test.doNothing() RETURN n":                      {"MATCH (n) CALL test.doNothing() RETURN n", true},
+		"CALL test.labels() YIELD label RETURN label":                   {"CALL test.labels() YIELD label RETURN label", true},
+		"CALL test.labels() YIELD label WHERE label = 'A' RETURN label": {"CALL test.labels() YIELD label WHERE label = 'A' RETURN label", true},
+		"WITH 'Hi' AS label CALL test.labels() YIELD label AS l":        {"WITH 'Hi' AS label CALL test.labels() YIELD label AS l RETURN l", true},
+		"UNWIND [1, 2] AS x CALL test.my.proc(x) YIELD out RETURN out":  {"UNWIND [1, 2] AS x CALL test.my.proc(x) YIELD out RETURN out", true},
+		"MATCH (n) CALL test.doNothing RETURN n":                        {"MATCH (n) CALL test.doNothing RETURN n", false},
+		"CALL test.labels() YIELD * RETURN label":                       {"CALL test.labels() YIELD * RETURN label", false},
+		"CALL test.labels() YIELD label WHERE RETURN label":             {"CALL test.labels() YIELD label WHERE RETURN label", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestCallQueryTypes(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("CALL test.my.proc('Dog', 'Cat') YIELD a AS b, c"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	call := tree.(*ast.StandaloneCall)
+	assert.Len(t, call.Procedure.ProcedureName.Namespace, 2)
+	assert.Len(t, call.Procedure.Args, 2)
+	assert.False(t, call.Procedure.Implicit)
+	assert.Len(t, call.YieldItems.Items, 2)
+	assert.NotNil(t, call.YieldItems.Items[0].Field)
+	assert.Nil(t, call.YieldItems.Items[1].Field)
+
+	s = scanner.New([]byte("MATCH (n) CALL test.doNothing() RETURN n"), reporter)
+	p = New(s, reporter)
+	tree, err = p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	assert.Len(t, query.ReadingClause, 2)
+	assert.IsType(t, &ast.InQueryCall{}, query.ReadingClause[1])
+}
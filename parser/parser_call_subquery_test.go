@@ -0,0 +1,50 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSubqueryCall(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"UNWIND [1, 2] AS row CALL { WITH row RETURN row * 2 AS doubled } RETURN doubled": {"UNWIND [1, 2] AS row CALL { WITH row RETURN row * 2 AS doubled } RETURN doubled", true},
+		"MATCH (n) CALL { MATCH (m) RETURN m } RETURN n, m":                               {"MATCH (n) CALL { MATCH (m) RETURN m } RETURN n, m", true},
+		"CALL { MATCH (n) RETURN n UNION MATCH (m) RETURN m AS n } RETURN n":              {"CALL { MATCH (n) RETURN n UNION MATCH (m) RETURN m AS n } RETURN n", true},
+		"UNWIND $rows AS row CALL { WITH row CREATE (:N {v: row}) }":                      {"UNWIND $rows AS row CALL { WITH row CREATE (:N {v: row}) }", true},
+		"... CALL { WITH row CREATE (:N) } IN TRANSACTIONS":                               {"UNWIND $rows AS row CALL { WITH row CREATE (:N) } IN TRANSACTIONS", true},
+		"... CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1000 ROWS":                  {"UNWIND $rows AS row CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1000 ROWS", true},
+		"... CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1 ROW":                      {"UNWIND $rows AS row CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1 ROW", true},
+		"MATCH (n) CALL { WITH n MATCH (n)-->(m) } RETURN n":                              {"MATCH (n) CALL { WITH n MATCH (n)-->(m) } RETURN n", true},
+		"CALL { MATCH (n) RETURN n } CALL { MATCH (m) RETURN m } RETURN n, m":             {"CALL { MATCH (n) RETURN n } CALL { MATCH (m) RETURN m } RETURN n, m", true},
+		"CALL { MATCH (n) RETURN n RETURN n":                                              {"CALL { MATCH (n) RETURN n RETURN n", false},
+		"CALL { } RETURN 1":                                                               {"CALL { } RETURN 1", false},
+		"... CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1000":                       {"UNWIND $rows AS row CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1000", false},
+		"MATCH (n) CALL { MATCH (m) RETURN m }":                                           {"MATCH (n) CALL { MATCH (m) RETURN m }", true},
+		"MATCH (n) CALL { MATCH (m) RETURN m } MATCH (o)":                                 {"MATCH (n) CALL { MATCH (m) RETURN m } MATCH (o)", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestSubqueryCallTransactions(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("UNWIND $rows AS row CALL { WITH row CREATE (:N) } IN TRANSACTIONS OF 1000 ROWS"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	assert.Len(t, query.ReadingClause, 2)
+	call := query.ReadingClause[1].(*ast.SubqueryCall)
+	assert.True(t, call.InTransactions)
+	assert.NotNil(t, call.BatchSize)
+	assert.IsType(t, &ast.MultiPartQuery{}, call.Query)
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/cst"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCST(t *testing.T) {
+	tests := map[string]struct {
+		src string
+	}{
+		"no trivia":        {"MATCH(n)RETURN n"},
+		"whitespace":       {"  MATCH (n)\n\tRETURN  n  \n"},
+		"line comments":    {"// all nodes\nMATCH (n) // every one\nRETURN n // done"},
+		"block comments":   {"/* header */ MATCH (n /* node */)-[/* any */]->(m) RETURN /* both */ n, m"},
+		"expressions":      {"RETURN 1 + /* two */ 2 * 3, [x IN l WHERE x > 1 | x], 'a\\'b', $p"},
+		"subquery":         {"CALL {\n  // inner\n  MATCH (n) RETURN n\n}\nRETURN n"},
+		"union":            {"RETURN 1 AS x\n/* then */ UNION ALL\nRETURN 2 AS x"},
+		"schema command":   {"CREATE INDEX /* name */ idx FOR (n:Person) ON (n.name)"},
+		"trailing comment": {"RETURN 1 /* unterminated"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reporter := newTestReporter()
+			p := New(scanner.New([]byte(tc.src), reporter), reporter)
+			tree, err := p.ParseCST()
+			require.NoError(t, err)
+			assert.Equal(t, tc.src, tree.Text())
+		})
+	}
+}
+
+func TestCSTNodes(t *testing.T) {
+	src := "MATCH (n:Person) // people\nWHERE n.age > /* min */ 30\nRETURN n.name"
+	reporter := newTestReporter()
+	p := New(scanner.New([]byte(src), reporter), reporter)
+	tree, err := p.ParseCST()
+	require.NoError(t, err)
+
+	query := tree.Node.(*ast.SinglePartQuery)
+	match := query.ReadingClause[0].(*ast.MatchClause)
+	node := tree.Find(match)
+	require.NotNil(t, node)
+	assert.Equal(t, "MATCH (n:Person) // people\nWHERE n.age > /* min */ 30", node.Text())
+
+	where := tree.Find(match.WhereExpr)
+	require.NotNil(t, where)
+	assert.Equal(t, " n.age > /* min */ 30", where.Text())
+	tokens := where.Tokens()
+	assert.Equal(t, scanner.GreaterThan, tokens[len(tokens)-2].T)
+	assert.Equal(t, []scanner.Trivia{
+		{Kind: scanner.Whitespace, Text: " ", Span: scanner.Span{Start: scanner.Pos{Offset: 40, Line: 2, Column: 14}, End: scanner.Pos{Offset: 41, Line: 2, Column: 15}}},
+		{Kind: scanner.BlockComment, Text: "/* min */", Span: scanner.Span{Start: scanner.Pos{Offset: 41, Line: 2, Column: 15}, End: scanner.Pos{Offset: 50, Line: 2, Column: 24}}},
+		{Kind: scanner.Whitespace, Text: " ", Span: scanner.Span{Start: scanner.Pos{Offset: 50, Line: 2, Column: 24}, End: scanner.Pos{Offset: 51, Line: 2, Column: 25}}},
+	}, tokens[len(tokens)-1].Trivia)
+
+	// The keywords of a clause belong to the clause, rather than to its children.
+	assert.Equal(t, scanner.Match, node.Children[0].(*cst.Token).T)
+	last := tree.Children[len(tree.Children)-1].(*cst.Token)
+	assert.Equal(t, scanner.EndOfInput, last.T)
+}
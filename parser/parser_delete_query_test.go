@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/delete
+
+// delete/Delete1.feature through delete/Delete6.feature
+func TestDelete(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n) DELETE n":                                    {"MATCH (n) DELETE n", true},
+		"MATCH (n) DETACH DELETE n":                             {"MATCH (n) DETACH DELETE n", true},
+		"OPTIONAL MATCH (a:DoesNotExist) DELETE a RETURN a":     {"OPTIONAL MATCH (a:DoesNotExist) DELETE a RETURN a", true},
+		"MATCH (n) DELETE n:Person":                             {"MATCH (n) DELETE n:Person", true},
+		"MATCH (n) OPTIONAL MATCH (n)-[r]-() DELETE n, r":       {"MATCH (n) OPTIONAL MATCH (n)-[r]-() DELETE n, r", true},
+		"MATCH p = ()-[r:T]-() WHERE r.id = 42 DELETE r":        {"MATCH p = ()-[r:T]-() WHERE r.id = 42 DELETE r", true},
+		"MATCH p = (:X)-->()-->()-->() DETACH DELETE p":         {"MATCH p = (:X)-->()-->()-->() DETACH DELETE p", true},
+		"MATCH (a)-[r]-(b) DELETE r, a, b RETURN count(*) AS c": {"MATCH (a)-[r]-(b) DELETE r, a, b RETURN count(*) AS c", true},
+		"MATCH () CREATE (n) DELETE n":                          {"MATCH () CREATE (n) DELETE n", true},
+		"MATCH (n) WITH collect(n) AS list DELETE list[0]":      {"MATCH (n) WITH collect(n) AS list DELETE list[0]", true},
+		"MATCH (n) WITH {key: n} AS map DETACH DELETE map.key":  {"MATCH (n) WITH {key: n} AS map DETACH DELETE map.key", true},
+		"MATCH () DELETE 1 + 1":                                 {"MATCH () DELETE 1 + 1", true},
+		"MATCH (n) DETACH n":                                    {"MATCH (n) DETACH n", false},
+		"MATCH (n) DELETE":                                      {"MATCH (n) DELETE", false},
+		"MATCH (n) DELETE n,":                                   {"MATCH (n) DELETE n,", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestDetachDelete(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (a)-[r]-(b) DELETE r DETACH DELETE a, b"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	assert.Len(t, query.UpdatingClause, 2)
+	assert.False(t, query.UpdatingClause[0].(*ast.DeleteClause).Detach)
+	assert.Len(t, query.UpdatingClause[0].(*ast.DeleteClause).Exprs, 1)
+	assert.True(t, query.UpdatingClause[1].(*ast.DeleteClause).Detach)
+	assert.Len(t, query.UpdatingClause[1].(*ast.DeleteClause).Exprs, 2)
+}
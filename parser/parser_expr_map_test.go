@@ -1,6 +1,9 @@
 package parser
 
 import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
@@ -23,3 +26,42 @@ func TestMapKeysFunction(t *testing.T) {
 		})
 	}
 }
+
+func TestMapProjection(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"n {.name, .age, friends: size(f)}": {"n {.name, .age, friends: size(f)}", true},
+		"n {.*}":                            {"n {.*}", true},
+		"n {.*, .name}":                     {"n {.*, .name}", true},
+		"n {m}":                             {"n {m}", true},
+		"n {}":                              {"n {}", true},
+		"n {.name, nested: m {.name}}":      {"n {.name, nested: m {.name}}", true},
+		"n {.name, count: 1}":               {"n {.name, count: 1}", true},
+		"n {.}":                             {"n {.}", false},
+		"n {.name,}":                        {"n {.name,}", false},
+		"n {.name":                          {"n {.name", false},
+		"n {key: }":                         {"n {key: }", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runExprTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestMapProjectionSelectors(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("n {.name, .*, key: 1 + 2, m}"), reporter)
+	p := New(s, reporter)
+	expr, err := p.expr()
+	assert.NoError(t, err)
+	projection := expr.(*ast.PropertyLabelsExpr).Atom.(*ast.MapProjectionExpr)
+	assert.Len(t, projection.Selectors, 4)
+	assert.IsType(t, &ast.PropertySelector{}, projection.Selectors[0])
+	assert.IsType(t, &ast.AllPropertiesSelector{}, projection.Selectors[1])
+	assert.IsType(t, &ast.LiteralEntrySelector{}, projection.Selectors[2])
+	assert.IsType(t, &ast.VariableSelector{}, projection.Selectors[3])
+}
@@ -0,0 +1,81 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for expressions.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/expressions/existentialSubqueries
+
+// existentialSubqueries/ExistentialSubquery1.feature
+func TestSimpleExistentialSubquery(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"exists { (n)-->() }":                         {"exists { (n)-->() }", true},
+		"exists { (n)-->(m) WHERE n.prop = m.prop }":  {"exists { (n)-->(m) WHERE n.prop = m.prop }", true},
+		"exists { (n)-[:NA]->() }":                    {"exists { (n)-[:NA]->() }", true},
+		"exists { (n)-[r]->() WHERE type(r) = 'NA' }": {"exists { (n)-[r]->() WHERE type(r) = 'NA' }", true},
+		"EXISTS { (n)-->(), (n)<--() }":               {"EXISTS { (n)-->(), (n)<--() }", true},
+		"COUNT { (n)-->() }":                          {"COUNT { (n)-->() }", true},
+		"count { (n)-->(m) WHERE m.x > 1 }":           {"count { (n)-->(m) WHERE m.x > 1 }", true},
+		"exists { (n)-->() ":                          {"exists { (n)-->() ", false},
+		"exists { (n)-->() WHERE }":                   {"exists { (n)-->() WHERE }", false},
+		"exists { }":                                  {"exists { }", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runExprTest(t, reporter, tc)
+		})
+	}
+}
+
+// existentialSubqueries/ExistentialSubquery2.feature and existentialSubqueries/ExistentialSubquery3.feature
+func TestFullExistentialSubquery(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n) WHERE exists { MATCH (n)-->() RETURN true } RETURN n":                  {"MATCH (n) WHERE exists { MATCH (n)-->() RETURN true } RETURN n", true},
+		"MATCH (n) WHERE exists { MATCH (n)-->(m) WITH n, count(*) AS numConnections ...": {"MATCH (n) WHERE exists { MATCH (n)-->(m) WITH n, count(*) AS numConnections WHERE numConnections = 3 RETURN true } RETURN n", true},
+		"MATCH (n) WHERE exists { MATCH (n)-->(m) SET m.prop='fail' } RETURN n":           {"MATCH (n) WHERE exists { MATCH (n)-->(m) SET m.prop='fail' } RETURN n", true},
+		"MATCH (n) WHERE exists { MATCH (m) WHERE exists { (n)-[]->(m) } RETURN true }":   {"MATCH (n) WHERE exists { MATCH (m) WHERE exists { (n)-[]->(m) WHERE n.prop = m.prop } RETURN true } RETURN n", true},
+		"MATCH (n) WHERE exists { MATCH (n)-->(m) } RETURN n":                             {"MATCH (n) WHERE exists { MATCH (n)-->(m) } RETURN n", true},
+		"MATCH (n) RETURN COUNT { MATCH (n)-->(m) RETURN m } AS c":                        {"MATCH (n) RETURN COUNT { MATCH (n)-->(m) RETURN m } AS c", true},
+		"MATCH (n) WHERE exists { MATCH (n)-->() RETURN true RETURN n":                    {"MATCH (n) WHERE exists { MATCH (n)-->() RETURN true RETURN n", false},
+		"MATCH (n) WHERE exists { RETURN } RETURN n":                                      {"MATCH (n) WHERE exists { RETURN } RETURN n", false},
+		"MATCH (n)-->(m) RETURN n":                                                        {"MATCH (n)-->(m) RETURN n", true},
+		"MATCH (n)-->(m)":                                                                 {"MATCH (n)-->(m)", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestSubqueryExprTypes(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("exists { (n)-->(m) WHERE m.x > 1 }"), reporter)
+	p := New(s, reporter)
+	expr, err := p.expr()
+	assert.NoError(t, err)
+	exists := expr.(*ast.PropertyLabelsExpr).Atom.(*ast.ExistsSubqueryExpr)
+	assert.NotNil(t, exists.Pattern)
+	assert.NotNil(t, exists.WhereExpr)
+	assert.Nil(t, exists.Query)
+
+	s = scanner.New([]byte("COUNT { MATCH (n)-->(m) RETURN m }"), reporter)
+	p = New(s, reporter)
+	expr, err = p.expr()
+	assert.NoError(t, err)
+	count := expr.(*ast.PropertyLabelsExpr).Atom.(*ast.CountSubqueryExpr)
+	assert.Nil(t, count.Pattern)
+	assert.IsType(t, &ast.SinglePartQuery{}, count.Query)
+}
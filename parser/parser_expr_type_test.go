@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTypePredicate(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"x IS :: INTEGER":                        {"x IS :: INTEGER", true},
+		"x IS NOT :: INTEGER":                    {"x IS NOT :: INTEGER", true},
+		"x IS TYPED STRING":                      {"x IS TYPED STRING", true},
+		"x IS NOT TYPED STRING":                  {"x IS NOT TYPED STRING", true},
+		"x :: BOOLEAN":                           {"x :: BOOLEAN", true},
+		"x IS :: STRING NOT NULL":                {"x IS :: STRING NOT NULL", true},
+		"x IS :: LIST<INTEGER>":                  {"x IS :: LIST<INTEGER>", true},
+		"x IS :: LIST<LIST<FLOAT NOT NULL>>":     {"x IS :: LIST<LIST<FLOAT NOT NULL>>", true},
+		"x IS :: INTEGER | STRING":               {"x IS :: INTEGER | STRING", true},
+		"x IS :: ANY<INTEGER | STRING> NOT NULL": {"x IS :: ANY<INTEGER | STRING> NOT NULL", true},
+		"x IS :: ANY":                            {"x IS :: ANY", true},
+		"x IS :: ANY VALUE":                      {"x IS :: ANY VALUE", true},
+		"x IS :: NOTHING":                        {"x IS :: NOTHING", true},
+		"x IS :: NULL":                           {"x IS :: NULL", true},
+		"x IS :: PROPERTY VALUE":                 {"x IS :: PROPERTY VALUE", true},
+		"x IS :: ANY PROPERTY VALUE":             {"x IS :: ANY PROPERTY VALUE", true},
+		"x IS :: ANY NODE":                       {"x IS :: ANY NODE", true},
+		"x IS :: SIGNED INTEGER":                 {"x IS :: SIGNED INTEGER", true},
+		"x IS :: LOCAL DATETIME":                 {"x IS :: LOCAL DATETIME", true},
+		"x IS :: ZONED TIME":                     {"x IS :: ZONED TIME", true},
+		"x IS :: TIME WITHOUT TIMEZONE":          {"x IS :: TIME WITHOUT TIMEZONE", true},
+		"x IS :: TIMESTAMP WITH TIMEZONE":        {"x IS :: TIMESTAMP WITH TIMEZONE", true},
+		"x IS :: TIME WITH TIME ZONE":            {"x IS :: TIME WITH TIME ZONE", true},
+		"x IS :: TIMESTAMP WITHOUT TIME ZONE":    {"x IS :: TIMESTAMP WITHOUT TIME ZONE", true},
+		"x IS :: INTEGER NOT NULL LIST":          {"x IS :: INTEGER NOT NULL LIST", true},
+		"x IS :: INTEGER AND y IS NULL":          {"x IS :: INTEGER AND y IS NULL", true},
+		"x IS ::":                                {"x IS ::", false},
+		"x IS :: NUMBER":                         {"x IS :: NUMBER", false},
+		"x IS :: LIST<INTEGER":                   {"x IS :: LIST<INTEGER", false},
+		"x IS :: LIST INTEGER":                   {"x IS :: LIST INTEGER", false},
+		"x IS :: PROPERTY":                       {"x IS :: PROPERTY", false},
+		"x IS :: TIME":                           {"x IS :: TIME", false},
+		"x IS :: TIME WITH TIME":                 {"x IS :: TIME WITH TIME", false},
+		"x IS :: TIME WITH ZONE":                 {"x IS :: TIME WITH ZONE", false},
+		"x IS :: INTEGER |":                      {"x IS :: INTEGER |", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runExprTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestTypePredicateAST(t *testing.T) {
+	tests := map[string]struct {
+		src       string
+		predicate *ast.TypePredicateExpr
+	}{
+		"IS :: INTEGER": {"x IS :: INTEGER", &ast.TypePredicateExpr{Type: &ast.SimpleType{Type: ast.IntegerValue}}},
+		"IS NOT :: STRING NOT NULL": {"x IS NOT :: STRING NOT NULL", &ast.TypePredicateExpr{
+			Not:  true,
+			Type: &ast.SimpleType{Type: ast.StringValue, NotNull: true},
+		}},
+		"LIST<INTEGER | FLOAT>": {"x :: LIST<INTEGER | FLOAT>", &ast.TypePredicateExpr{
+			Type: &ast.ListType{Element: &ast.UnionType{Types: []ast.CypherType{
+				&ast.SimpleType{Type: ast.IntegerValue},
+				&ast.SimpleType{Type: ast.FloatValue},
+			}}},
+		}},
+		"ANY<BOOLEAN> NOT NULL": {"x IS :: ANY<BOOLEAN> NOT NULL", &ast.TypePredicateExpr{
+			Type: &ast.UnionType{Types: []ast.CypherType{&ast.SimpleType{Type: ast.BooleanValue}}, NotNull: true},
+		}},
+		"INTEGER NOT NULL LIST NOT NULL": {"x IS :: INTEGER NOT NULL LIST NOT NULL", &ast.TypePredicateExpr{
+			Type: &ast.ListType{Element: &ast.SimpleType{Type: ast.IntegerValue, NotNull: true}, NotNull: true},
+		}},
+		"TIME WITH TIMEZONE":  {"x IS :: TIME WITH TIMEZONE", &ast.TypePredicateExpr{Type: &ast.SimpleType{Type: ast.ZonedTimeValue}}},
+		"TIME WITH TIME ZONE": {"x IS :: TIME WITH TIME ZONE", &ast.TypePredicateExpr{Type: &ast.SimpleType{Type: ast.ZonedTimeValue}}},
+		"TIMESTAMP WITHOUT TIME ZONE": {"x IS :: TIMESTAMP WITHOUT TIME ZONE", &ast.TypePredicateExpr{
+			Type: &ast.SimpleType{Type: ast.LocalDateTimeValue},
+		}},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := scanner.New([]byte(tc.src), reporter)
+			p := New(s, reporter)
+			tree, err := p.expr()
+			assert.NoError(t, err)
+			list := tree.(*ast.BinaryExpr).Right.(*ast.ListExpr)
+			clearSpans(list)
+			assert.Equal(t, []ast.Expr{tc.predicate}, list.List)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestForeach(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n) FOREACH (x IN [1, 2] | CREATE (:N {v: x}) SET n.v = x)":  {"MATCH (n) FOREACH (x IN [1, 2] | CREATE (:N {v: x}) SET n.v = x)", true},
+		"MATCH p = (a)-->(b) FOREACH (n IN nodes(p) | SET n.marked = true)": {"MATCH p = (a)-->(b) FOREACH (n IN nodes(p) | SET n.marked = true)", true},
+		"FOREACH (x IN [[1]] | FOREACH (y IN x | CREATE (:N {v: y})))":      {"FOREACH (x IN [[1]] | FOREACH (y IN x | CREATE (:N {v: y})))", true},
+		"MATCH (n) foreach (x IN [1] | MERGE (:N {v: x}) REMOVE n:Old)":     {"MATCH (n) foreach (x IN [1] | MERGE (:N {v: x}) REMOVE n:Old)", true},
+		"MATCH (n) FOREACH (m IN [n] | DETACH DELETE m) RETURN count(*)":    {"MATCH (n) FOREACH (m IN [n] | DETACH DELETE m) RETURN count(*)", true},
+		"FOREACH (x IN [1] | MATCH (n) SET n.v = x)":                        {"FOREACH (x IN [1] | MATCH (n) SET n.v = x)", false},
+		"FOREACH (x IN [1] | CREATE (:N) RETURN x)":                         {"FOREACH (x IN [1] | CREATE (:N) RETURN x)", false},
+		"FOREACH (x IN [1] | )":           {"FOREACH (x IN [1] | )", false},
+		"FOREACH (x IN [1] CREATE (:N))":  {"FOREACH (x IN [1] CREATE (:N))", false},
+		"FOREACH ([1] | CREATE (:N))":     {"FOREACH ([1] | CREATE (:N))", false},
+		"FOREACH (x IN [1] | CREATE (:N)": {"FOREACH (x IN [1] | CREATE (:N)", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestForeachClauses(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("FOREACH (x IN [1] | CREATE (:N) FOREACH (y IN [x] | SET y.v = 1))"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	foreach := query.UpdatingClause[0].(*ast.ForeachClause)
+	assert.Len(t, foreach.UpdatingClauses, 2)
+	assert.IsType(t, &ast.CreateClause{}, foreach.UpdatingClauses[0])
+	assert.IsType(t, &ast.ForeachClause{}, foreach.UpdatingClauses[1])
+}
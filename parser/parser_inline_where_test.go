@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInlineWhere(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n:Person WHERE n.age > 30) RETURN n":                {"MATCH (n:Person WHERE n.age > 30) RETURN n", true},
+		"MATCH (n WHERE n.age > 30) RETURN n":                       {"MATCH (n WHERE n.age > 30) RETURN n", true},
+		"MATCH (n:Person {name: 'A'} WHERE n.age > 30) RETURN n":    {"MATCH (n:Person {name: 'A'} WHERE n.age > 30) RETURN n", true},
+		"MATCH ()-[r:KNOWS WHERE r.since < 2000]->() RETURN r":      {"MATCH ()-[r:KNOWS WHERE r.since < 2000]->() RETURN r", true},
+		"MATCH ()-[r:KNOWS*1..2 WHERE r.since < 2000]->() RETURN r": {"MATCH ()-[r:KNOWS*1..2 WHERE r.since < 2000]->() RETURN r", true},
+		"MATCH (a WHERE a:A|B)-[r WHERE r.x = 1]->(b) RETURN a":     {"MATCH (a WHERE a:A|B)-[r WHERE r.x = 1]->(b) RETURN a", true},
+		"MATCH (n) RETURN [(n)-->(m WHERE m:A|B) | m]":              {"MATCH (n) RETURN [(n)-->(m WHERE m:A|B) | m]", true},
+		"MATCH (n WHERE) RETURN n":                                  {"MATCH (n WHERE) RETURN n", false},
+		"MATCH (n WHERE n.age > 30 RETURN n":                        {"MATCH (n WHERE n.age > 30 RETURN n", false},
+		"MATCH ()-[r WHERE r.since < 2000->() RETURN r":             {"MATCH ()-[r WHERE r.since < 2000->() RETURN r", false},
+		"MATCH (n WHERE n.age > 30 {name: 'A'}) RETURN n":           {"MATCH (n WHERE n.age > 30 {name: 'A'}) RETURN n", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestInlineWhereAST(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n:Person WHERE n.age > 30)-[r:KNOWS WHERE r.since < 2000]->(m) RETURN n"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	element := match.Pattern.Parts[0].Element.(*ast.PatternElementPattern)
+	assert.IsType(t, &ast.BinaryExpr{}, element.Left.WhereExpr)
+	assert.IsType(t, &ast.BinaryExpr{}, element.Chain[0].RelationshipPattern.RelationshipDetail.WhereExpr)
+	assert.Nil(t, element.Chain[0].Right.WhereExpr)
+}
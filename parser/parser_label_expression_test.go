@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/mburbidg/cypher/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLabelExpression(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n:A|B) RETURN n":                                   {"MATCH (n:A|B) RETURN n", true},
+		"MATCH (n:A&B) RETURN n":                                   {"MATCH (n:A&B) RETURN n", true},
+		"MATCH (n:!A) RETURN n":                                    {"MATCH (n:!A) RETURN n", true},
+		"MATCH (n:%) RETURN n":                                     {"MATCH (n:%) RETURN n", true},
+		"MATCH (n:(A|B)&!C) RETURN n":                              {"MATCH (n:(A|B)&!C) RETURN n", true},
+		"MATCH (n:A:B) RETURN n":                                   {"MATCH (n:A:B) RETURN n", true},
+		"MATCH (:A|B {name: 'x'}) RETURN 1":                        {"MATCH (:A|B {name: 'x'}) RETURN 1", true},
+		"MATCH ()-[r:R|S]->() RETURN r":                            {"MATCH ()-[r:R|S]->() RETURN r", true},
+		"MATCH ()-[r:R|:S]->() RETURN r":                           {"MATCH ()-[r:R|:S]->() RETURN r", true},
+		"MATCH ()-[r:!R]->() RETURN r":                             {"MATCH ()-[r:!R]->() RETURN r", true},
+		"MATCH ()-[r:%]->() RETURN r":                              {"MATCH ()-[r:%]->() RETURN r", true},
+		"MATCH (n) WHERE n:A|B RETURN n":                           {"MATCH (n) WHERE n:A|B RETURN n", true},
+		"MATCH (n) WHERE n:A&!B RETURN n":                          {"MATCH (n) WHERE n:A&!B RETURN n", true},
+		"MATCH (n) RETURN [x IN [n] WHERE x:A | x]":                {"MATCH (n) RETURN [x IN [n] WHERE x:A | x]", true},
+		"MATCH (n) RETURN [x IN [n] WHERE x:(A|B) | x]":            {"MATCH (n) RETURN [x IN [n] WHERE x:(A|B) | x]", true},
+		"MATCH (n) RETURN [(n)-->(m) WHERE m:A | m]":               {"MATCH (n) RETURN [(n)-->(m) WHERE m:A | m]", true},
+		"MATCH (n) RETURN [x IN [n] WHERE EXISTS { (x:A|B) } | x]": {"MATCH (n) RETURN [x IN [n] WHERE EXISTS { (x:A|B) } | x]", true},
+		"MATCH (n:(A)) RETURN n":                                   {"MATCH (n:(A)) RETURN n", true},
+		"MATCH (n:((A))) RETURN n":                                 {"MATCH (n:((A))) RETURN n", true},
+		"MATCH (n:(A):B) RETURN n":                                 {"MATCH (n:(A):B) RETURN n", true},
+		"MATCH (n) WHERE n:(A) RETURN n":                           {"MATCH (n) WHERE n:(A) RETURN n", true},
+		"MATCH (n) RETURN [x IN [n] WHERE x:A:B | x]":              {"MATCH (n) RETURN [x IN [n] WHERE x:A:B | x]", true},
+		"MATCH (n:A|) RETURN n":                                    {"MATCH (n:A|) RETURN n", false},
+		"MATCH (n:A&) RETURN n":                                    {"MATCH (n:A&) RETURN n", false},
+		"MATCH (n:!) RETURN n":                                     {"MATCH (n:!) RETURN n", false},
+		"MATCH (n:(A|B) RETURN n":                                  {"MATCH (n:(A|B) RETURN n", false},
+		"MATCH (n:A:B|C) RETURN n":                                 {"MATCH (n:A:B|C) RETURN n", false},
+		"MATCH (n:A|B:C) RETURN n":                                 {"MATCH (n:A|B:C) RETURN n", false},
+		"MATCH (n:A&B:C) RETURN n":                                 {"MATCH (n:A&B:C) RETURN n", false},
+		"MATCH (n) WHERE n:A:B|C RETURN n":                         {"MATCH (n) WHERE n:A:B|C RETURN n", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestLabelExpressionMixedForms(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		line int
+	}{
+		"conjunction then disjunction": {"MATCH (n:A:B|C)\nRETURN n", 1},
+		"disjunction then conjunction": {"MATCH (n:A|B:C)\nRETURN n", 1},
+		"and then conjunction":         {"MATCH (n:A&B:C)\nRETURN n", 1},
+		"label predicate":              {"MATCH (n)\nWHERE n:A:B|C\nRETURN n", 2},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reporter := newTestReporter()
+			p := New(scanner.New([]byte(tc.src), reporter), reporter)
+			_, err := p.query()
+			assert.Equal(t, utils.ParseError{Line: tc.line, Msg: "cannot mix ':' label conjunction with label expression operators"}, err)
+		})
+	}
+}
+
+func TestLabelExpressionPrecedence(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n:A|B&!C) RETURN n"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	node := match.Pattern.Parts[0].Element.(*ast.PatternElementPattern).Left
+	assert.Nil(t, node.Labels)
+	or := node.LabelExpr.(*ast.LabelOr)
+	assert.IsType(t, &ast.LabelName{}, or.Left)
+	and := or.Right.(*ast.LabelAnd)
+	assert.IsType(t, &ast.LabelName{}, and.Left)
+	not := and.Right.(*ast.LabelNot)
+	assert.IsType(t, &ast.LabelName{}, not.Expr)
+}
+
+func TestLabelExpressionForms(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n:A:B)-[r:R|S]->(m:%)-[q:!R]->() WHERE n:A|B RETURN n"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	element := match.Pattern.Parts[0].Element.(*ast.PatternElementPattern)
+
+	// Simple labels and type disjunctions keep their list form.
+	assert.Len(t, element.Left.Labels, 2)
+	assert.Nil(t, element.Left.LabelExpr)
+	assert.Len(t, element.Chain[0].RelationshipPattern.RelationshipDetail.RelationshipTypes, 2)
+	assert.Nil(t, element.Chain[0].RelationshipPattern.RelationshipDetail.LabelExpr)
+
+	// Anything else is held as a label expression.
+	assert.IsType(t, &ast.LabelWildcard{}, element.Chain[0].Right.LabelExpr)
+	assert.Nil(t, element.Chain[1].RelationshipPattern.RelationshipDetail.RelationshipTypes)
+	assert.IsType(t, &ast.LabelNot{}, element.Chain[1].RelationshipPattern.RelationshipDetail.LabelExpr)
+
+	where := match.WhereExpr.(*ast.PropertyLabelsExpr)
+	assert.IsType(t, &ast.LabelOr{}, where.LabelExpr)
+}
+
+func TestLabelExpressionParenthesizedName(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n:((A)):B) WHERE n:(C) RETURN n"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	node := match.Pattern.Parts[0].Element.(*ast.PatternElementPattern).Left
+
+	// A name in parentheses is a simple label, like a single type of a relationship pattern.
+	assert.Len(t, node.Labels, 2)
+	assert.Nil(t, node.LabelExpr)
+	where := match.WhereExpr.(*ast.PropertyLabelsExpr)
+	assert.Len(t, where.Labels, 1)
+	assert.Nil(t, where.LabelExpr)
+}
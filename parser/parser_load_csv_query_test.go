@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLoadCSV(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"LOAD CSV FROM 'file:///x.csv' AS line RETURN line[0]":                   {"LOAD CSV FROM 'file:///x.csv' AS line RETURN line[0]", true},
+		"LOAD CSV WITH HEADERS FROM $url AS row RETURN row.name":                 {"LOAD CSV WITH HEADERS FROM $url AS row RETURN row.name", true},
+		"LOAD CSV WITH HEADERS FROM $url AS row FIELDTERMINATOR ';' CREATE (:N)": {"LOAD CSV WITH HEADERS FROM $url AS row FIELDTERMINATOR ';' CREATE (:N {name: row.name})", true},
+		"load csv from $base + 'x.csv' as row fieldterminator '\\t' return row":  {"load csv from $base + 'x.csv' as row fieldterminator '\\t' return row", true},
+		"MATCH (n) LOAD CSV FROM n.url AS row WITH n, row SET n.value = row[1]":  {"MATCH (n) LOAD CSV FROM n.url AS row WITH n, row SET n.value = row[1]", true},
+		"LOAD CSV FROM $url RETURN 1":                                            {"LOAD CSV FROM $url RETURN 1", false},
+		"LOAD CSV WITH FROM $url AS row RETURN row":                              {"LOAD CSV WITH FROM $url AS row RETURN row", false},
+		"LOAD CSV $url AS row RETURN row":                                        {"LOAD CSV $url AS row RETURN row", false},
+		"LOAD CSV FROM $url AS RETURN 1":                                         {"LOAD CSV FROM $url AS RETURN 1", false},
+		"LOAD CSV FROM $url AS row FIELDTERMINATOR RETURN row":                   {"LOAD CSV FROM $url AS row FIELDTERMINATOR RETURN row", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestLoadCSVClause(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("LOAD CSV WITH HEADERS FROM $url AS row FIELDTERMINATOR ';' RETURN row"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	load := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.LoadCSVClause)
+	assert.True(t, load.WithHeaders)
+	assert.IsType(t, &ast.Parameter{}, load.URL.(*ast.PropertyLabelsExpr).Atom)
+	assert.Equal(t, ";", load.FieldTerminator)
+}
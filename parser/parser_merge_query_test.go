@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/merge
+
+// merge/Merge1.feature
+func TestMergeNode(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MERGE (a) RETURN count(*) AS n":                      {"MERGE (a) RETURN count(*) AS n", true},
+		"MERGE (a:TheLabel) RETURN labels(a)":                 {"MERGE (a:TheLabel) RETURN labels(a)", true},
+		"MERGE (a:TheLabel {num: 42}) RETURN a.num AS num":    {"MERGE (a:TheLabel {num: 42}) RETURN a.num AS num", true},
+		"MATCH (person:Person) MERGE (city:City {name: p.b})": {"MATCH (person:Person) MERGE (city:City {name: person.bornIn})", true},
+		"MATCH (a) MERGE (a)":                                 {"MATCH (a) MERGE (a)", true},
+		"MERGE (n $param) RETURN n":                           {"MERGE (n $param) RETURN n", true},
+		"MERGE":                                               {"MERGE", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+// merge/Merge2.feature, merge/Merge3.feature, merge/Merge4.feature
+func TestMergeActions(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MERGE (a:TheLabel) ON CREATE SET a.num = 42":          {"MERGE (a:TheLabel) ON CREATE SET a.num = 42 RETURN a.num", true},
+		"MERGE (a:TheLabel) ON MATCH SET a.num = 42":           {"MERGE (a:TheLabel) ON MATCH SET a.num = 42 RETURN a.num", true},
+		"MERGE (n) ON CREATE SET x.num = 1":                    {"MERGE (n) ON CREATE SET x.num = 1", true},
+		"MERGE (a) ON CREATE SET a.x = 1 ON MATCH SET a.y = 2": {"MERGE (a:TheLabel) ON CREATE SET a.created = 1 ON MATCH SET a.matched = 2", true},
+		"MERGE (a) ON MATCH SET a.x = 1, a.y = 2":              {"MERGE (a) ON MATCH SET a.x = 1, a.y = 2", true},
+		"MERGE (a) ON MATCH SET a.b.c = 1":                     {"MERGE (a) ON MATCH SET a.b.c = 1", true},
+		"MERGE (a) ON SET a.num = 42":                          {"MERGE (a) ON SET a.num = 42", false},
+		"MERGE (a) ON CREATE a.num = 42":                       {"MERGE (a) ON CREATE a.num = 42", false},
+		"MERGE (a) ON CREATE SET a = 42":                       {"MERGE (a) ON CREATE SET a 42", false},
+		"MERGE (a) ON CREATE SET a.num 42":                     {"MERGE (a) ON CREATE SET a.num 42", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+// merge/Merge5.feature, merge/Merge6.feature, merge/Merge7.feature
+func TestMergeRelationship(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE]->(b) RETURN count(*)":          {"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE]->(b) RETURN count(*)", true},
+		"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE {name: 'r2'}]->(b) RETURN r":    {"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE {name: 'r2'}]->(b) RETURN r", true},
+		"MATCH (a:A), (b:B) MERGE (a)<-[r:TYPE]-(b) RETURN r":                 {"MATCH (a:A), (b:B) MERGE (a)<-[r:TYPE]-(b) RETURN r", true},
+		"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE]-(b) RETURN r":                  {"MATCH (a:A), (b:B) MERGE (a)-[r:TYPE]-(b) RETURN r", true},
+		"MERGE (a:A)-[:KNOWS]->(b:B)":                                         {"MERGE (a:A)-[:KNOWS]->(b:B)", true},
+		"MATCH (a), (b) MERGE (a)-[r:TYPE]->(b) ON CREATE SET r.name = 'foo'": {"MATCH (a), (b) MERGE (a)-[r:TYPE]->(b) ON CREATE SET r.name = 'foo'", true},
+		"CREATE (a), (b) MERGE (a)-->(b)":                                     {"CREATE (a), (b) MERGE (a)-->(b)", true},
+		"MERGE (a)-[:FOO*2]->(b)":                                             {"MERGE (a) MERGE (b) MERGE (a)-[:FOO*2]->(b)", true},
+		"MERGE p = (a)-[:R]->(b) RETURN p":                                    {"MERGE p = (a)-[:R]->(b) RETURN p", true},
+		"MERGE (a)-[:R]->":                                                    {"MERGE (a)-[:R]->", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/expressions/path
+
+// path/Path1.feature, path/Path2.feature and path/Path3.feature
+func TestNamedPath(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"WITH null AS a OPTIONAL MATCH p = (a)-[r]->() RETURN nodes(p)": {"WITH null AS a OPTIONAL MATCH p = (a)-[r]->() RETURN nodes(p), nodes(null)", true},
+		"MATCH p = (a:Start)-[:REL*2..2]->(b) RETURN relationships(p)":  {"MATCH p = (a:Start)-[:REL*2..2]->(b) RETURN relationships(p)", true},
+		"MATCH p = (a)-[*0..1]->(b) RETURN a, b, length(p) AS l":        {"MATCH p = (a)-[*0..1]->(b) RETURN a, b, length(p) AS l", true},
+		"MATCH p = (a), q = (b) RETURN p, q":                            {"MATCH p = (a), q = (b) RETURN p, q", true},
+		"CREATE p = (a)-[:T]->(b) RETURN p":                             {"CREATE p = (a)-[:T]->(b) RETURN p", true},
+		"MATCH p (a)-->(b) RETURN p":                                    {"MATCH p (a)-->(b) RETURN p", false},
+		"MATCH p = RETURN p":                                            {"MATCH p = RETURN p", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestNamedPathVariable(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH p = (a)-->(b), (c) RETURN p"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	assert.Len(t, match.Pattern.Parts, 2)
+	assert.NotNil(t, match.Pattern.Parts[0].Variable)
+	assert.Nil(t, match.Pattern.Parts[1].Variable)
+}
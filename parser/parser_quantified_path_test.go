@@ -0,0 +1,108 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestQuantifiedPath(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH ((a)-[:R]->(b)){1,5} RETURN a":                  {"MATCH ((a)-[:R]->(b)){1,5} RETURN a", true},
+		"MATCH ((a)-[:R]->(b))+ RETURN a":                      {"MATCH ((a)-[:R]->(b))+ RETURN a", true},
+		"MATCH ((a)-[:R]->(b))* RETURN a":                      {"MATCH ((a)-[:R]->(b))* RETURN a", true},
+		"MATCH ((a)-[:R]->(b)){3} RETURN a":                    {"MATCH ((a)-[:R]->(b)){3} RETURN a", true},
+		"MATCH ((a)-[:R]->(b)){2,} RETURN a":                   {"MATCH ((a)-[:R]->(b)){2,} RETURN a", true},
+		"MATCH ((a)-[:R]->(b)){,4} RETURN a":                   {"MATCH ((a)-[:R]->(b)){,4} RETURN a", true},
+		"MATCH ((a)-[:R]->(b)) RETURN a":                       {"MATCH ((a)-[:R]->(b)) RETURN a", true},
+		"MATCH (x) ((a)-[:R]->(b))+ (y) RETURN x, y":           {"MATCH (x) ((a)-[:R]->(b))+ (y) RETURN x, y", true},
+		"MATCH (x)-->(z) ((a)-[:R]->(b))+ (y)-->() RETURN x":   {"MATCH (x)-->(z) ((a)-[:R]->(b))+ (y)-->() RETURN x", true},
+		"MATCH p = ((a)-[:R]->(b))+ RETURN p":                  {"MATCH p = ((a)-[:R]->(b))+ RETURN p", true},
+		"MATCH p = ANY SHORTEST (a)-[:R]->(b) RETURN p":        {"MATCH p = ANY SHORTEST (a)-[:R]->(b) RETURN p", true},
+		"MATCH p = ALL SHORTEST (a) ((x)-->(y))+ (b) RETURN p": {"MATCH p = ALL SHORTEST (a) ((x)-->(y))+ (b) RETURN p", true},
+		"MATCH p = SHORTEST 3 (a)-[:R]->(b) RETURN p":          {"MATCH p = SHORTEST 3 (a)-[:R]->(b) RETURN p", true},
+		"MATCH ANY SHORTEST (a)-[:R]->(b) RETURN a":            {"MATCH ANY SHORTEST (a)-[:R]->(b) RETURN a", true},
+		"MATCH ((a)-[:R]->(b)){} RETURN a":                     {"MATCH ((a)-[:R]->(b)){} RETURN a", false},
+		"MATCH ((a)-[:R]->(b)){5,1} RETURN a":                  {"MATCH ((a)-[:R]->(b)){5,1} RETURN a", false},
+		"MATCH ((a)-[:R]->(b)){1,5 RETURN a":                   {"MATCH ((a)-[:R]->(b)){1,5 RETURN a", false},
+		"MATCH ((a)-[:R]->(b) RETURN a":                        {"MATCH ((a)-[:R]->(b) RETURN a", false},
+		"MATCH p = SHORTEST (a)-[:R]->(b) RETURN p":            {"MATCH p = SHORTEST (a)-[:R]->(b) RETURN p", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestQuantifiers(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		begin int64
+		end   int64
+	}{
+		"+":     {"MATCH ((a)-->(b))+ RETURN a", 1, math.MaxInt64},
+		"*":     {"MATCH ((a)-->(b))* RETURN a", 0, math.MaxInt64},
+		"{3}":   {"MATCH ((a)-->(b)){3} RETURN a", 3, 3},
+		"{1,5}": {"MATCH ((a)-->(b)){1,5} RETURN a", 1, 5},
+		"{2,}":  {"MATCH ((a)-->(b)){2,} RETURN a", 2, math.MaxInt64},
+		"{,4}":  {"MATCH ((a)-->(b)){,4} RETURN a", 0, 4},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := scanner.New([]byte(tc.src), reporter)
+			p := New(s, reporter)
+			tree, err := p.query()
+			assert.NoError(t, err)
+			match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+			nested := match.Pattern.Parts[0].Element.(*ast.PatternElementNested)
+			clearSpans(nested)
+			assert.Equal(t, &ast.RangeLiteral{Begin: tc.begin, End: tc.end}, nested.Quantifier)
+		})
+	}
+}
+
+func TestPathSelectors(t *testing.T) {
+	tests := map[string]struct {
+		src      string
+		selector *ast.PathSelector
+	}{
+		"ANY SHORTEST": {"MATCH p = ANY SHORTEST (a)-->(b) RETURN p", &ast.PathSelector{Kind: ast.AnyShortestPath}},
+		"ALL SHORTEST": {"MATCH p = ALL SHORTEST (a)-->(b) RETURN p", &ast.PathSelector{Kind: ast.AllShortestPaths}},
+		"SHORTEST 2":   {"MATCH p = SHORTEST 2 (a)-->(b) RETURN p", &ast.PathSelector{Kind: ast.ShortestKPaths, Count: 2}},
+		"none":         {"MATCH p = (a)-->(b) RETURN p", nil},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := scanner.New([]byte(tc.src), reporter)
+			p := New(s, reporter)
+			tree, err := p.query()
+			assert.NoError(t, err)
+			match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+			clearSpans(match)
+			assert.Equal(t, tc.selector, match.Pattern.Parts[0].Selector)
+		})
+	}
+}
+
+func TestPatternElementSequence(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (x) ((a)-[:R]->(b)){1,5} (y) RETURN x"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	match := tree.(*ast.SinglePartQuery).ReadingClause[0].(*ast.MatchClause)
+	sequence := match.Pattern.Parts[0].Element.(*ast.PatternElementSequence)
+	assert.Len(t, sequence.Elements, 3)
+	assert.IsType(t, &ast.PatternElementPattern{}, sequence.Elements[0])
+	assert.IsType(t, &ast.PatternElementNested{}, sequence.Elements[1])
+	assert.IsType(t, &ast.PatternElementPattern{}, sequence.Elements[2])
+}
@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/remove
+
+// remove/Remove1.feature, remove/Remove2.feature, remove/Remove3.feature
+func TestRemove(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n) REMOVE n.num RETURN n.num":           {"MATCH (n) REMOVE n.num RETURN n.num", true},
+		"MATCH (n) REMOVE n.num, n.name RETURN size(n)": {"MATCH (n) REMOVE n.num, n.name RETURN size(keys(n))", true},
+		"MATCH ()-[r]->() REMOVE r.num RETURN r":        {"MATCH ()-[r]->() REMOVE r.num RETURN r", true},
+		"MATCH (n) REMOVE (n).num":                      {"MATCH (n) REMOVE (n).num", true},
+		"MATCH (n) REMOVE n.a.b":                        {"MATCH (n) REMOVE n.a.b", true},
+		"MATCH (n) REMOVE n:L RETURN n":                 {"MATCH (n) REMOVE n:L RETURN n", true},
+		"MATCH (n) REMOVE n:L1:L3 RETURN labels(n)":     {"MATCH (n) REMOVE n:L1:L3 RETURN labels(n)", true},
+		"MATCH (n) REMOVE n:Foo, n.num RETURN n":        {"MATCH (n) REMOVE n:Foo, n.num RETURN n", true},
+		"MATCH (n) REMOVE n.num SET n.x = 1 RETURN n":   {"MATCH (n) REMOVE n.num SET n.x = 1 RETURN n", true},
+		"MATCH (n) REMOVE n RETURN n":                   {"MATCH (n) REMOVE n RETURN n", false},
+		"MATCH (n) REMOVE n:":                           {"MATCH (n) REMOVE n:", false},
+		"MATCH (n) REMOVE RETURN n":                     {"MATCH (n) REMOVE RETURN n", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestRemoveItemTypes(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n) REMOVE n:Foo:Bar, n.num"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	assert.Len(t, query.UpdatingClause, 1)
+	remove := query.UpdatingClause[0].(*ast.RemoveClause)
+	assert.Len(t, remove.Items, 2)
+	assert.IsType(t, &ast.LabelsRemoveItem{}, remove.Items[0])
+	assert.Len(t, remove.Items[0].(*ast.LabelsRemoveItem).Labels, 2)
+	assert.IsType(t, &ast.PropertyRemoveItem{}, remove.Items[1])
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIndexCommands(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"CREATE INDEX FOR (n:Person) ON (n.name)":                    {"CREATE INDEX FOR (n:Person) ON (n.name)", true},
+		"CREATE INDEX person_name FOR (n:Person) ON (n.name, n.age)": {"CREATE INDEX person_name FOR (n:Person) ON (n.name, n.age)", true},
+		"CREATE INDEX IF NOT EXISTS FOR (n:Person) ON (n.name)":      {"CREATE INDEX IF NOT EXISTS FOR (n:Person) ON (n.name)", true},
+		"CREATE INDEX person_name IF NOT EXISTS FOR (n:Person)":      {"CREATE INDEX person_name IF NOT EXISTS FOR (n:Person) ON (n.name)", true},
+		"CREATE INDEX FOR ()-[r:KNOWS]-() ON (r.since)":              {"CREATE INDEX FOR ()-[r:KNOWS]-() ON (r.since)", true},
+		"CREATE INDEX FOR ()-[r:KNOWS]->() ON (r.since)":             {"CREATE INDEX FOR ()-[r:KNOWS]->() ON (r.since)", true},
+		"CREATE INDEX ON :Person(name)":                              {"CREATE INDEX ON :Person(name)", true},
+		"CREATE INDEX ON :Person(name, age)":                         {"CREATE INDEX ON :Person(name, age)", true},
+		"DROP INDEX person_name":                                     {"DROP INDEX person_name", true},
+		"DROP INDEX person_name IF EXISTS":                           {"DROP INDEX person_name IF EXISTS", true},
+		"DROP INDEX ON :Person(name)":                                {"DROP INDEX ON :Person(name)", true},
+		"CREATE INDEX FOR (n:Person)":                                {"CREATE INDEX FOR (n:Person)", false},
+		"CREATE INDEX FOR (n:Person) ON (n.name":                     {"CREATE INDEX FOR (n:Person) ON (n.name", false},
+		"CREATE INDEX FOR (n) ON (n.name)":                           {"CREATE INDEX FOR (n) ON (n.name)", false},
+		"CREATE INDEX ON :Person":                                    {"CREATE INDEX ON :Person", false},
+		"DROP INDEX":                                                 {"DROP INDEX", false},
+		"DROP INDEX person_name RETURN 1":                            {"DROP INDEX person_name RETURN 1", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runStatementTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestConstraintCommands(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email IS UNIQUE":           {"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email IS UNIQUE", true},
+		"CREATE CONSTRAINT uniq IF NOT EXISTS FOR (n:Person) REQUIRE":          {"CREATE CONSTRAINT uniq IF NOT EXISTS FOR (n:Person) REQUIRE n.email IS UNIQUE", true},
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE (n.first, n.last) IS UNIQUE": {"CREATE CONSTRAINT FOR (n:Person) REQUIRE (n.first, n.last) IS UNIQUE", true},
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.name IS NOT NULL":          {"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.name IS NOT NULL", true},
+		"CREATE CONSTRAINT FOR ()-[r:KNOWS]-() REQUIRE r.since IS NOT NULL":    {"CREATE CONSTRAINT FOR ()-[r:KNOWS]-() REQUIRE r.since IS NOT NULL", true},
+		"CREATE CONSTRAINT ON (n:Person) ASSERT n.email IS UNIQUE":             {"CREATE CONSTRAINT ON (n:Person) ASSERT n.email IS UNIQUE", true},
+		"CREATE CONSTRAINT ON (n:Person) ASSERT exists(n.name)":                {"CREATE CONSTRAINT ON (n:Person) ASSERT exists(n.name)", true},
+		"DROP CONSTRAINT uniq":                                         {"DROP CONSTRAINT uniq", true},
+		"DROP CONSTRAINT uniq IF EXISTS":                               {"DROP CONSTRAINT uniq IF EXISTS", true},
+		"DROP CONSTRAINT ON (n:Person) ASSERT n.email IS UNIQUE":       {"DROP CONSTRAINT ON (n:Person) ASSERT n.email IS UNIQUE", true},
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email":             {"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email", false},
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email IS NULL":     {"CREATE CONSTRAINT FOR (n:Person) REQUIRE n.email IS NULL", false},
+		"CREATE CONSTRAINT FOR (n:Person) n.email IS UNIQUE":           {"CREATE CONSTRAINT FOR (n:Person) n.email IS UNIQUE", false},
+		"CREATE CONSTRAINT FOR (n:Person) REQUIRE (n.a, n.b IS UNIQUE": {"CREATE CONSTRAINT FOR (n:Person) REQUIRE (n.a, n.b IS UNIQUE", false},
+		"DROP CONSTRAINT": {"DROP CONSTRAINT", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runStatementTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestStatementTypes(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("CREATE CONSTRAINT uniq FOR (n:Person) REQUIRE (n.first, n.last) IS UNIQUE"), reporter)
+	p := New(s, reporter)
+	stmt, err := p.Parse()
+	assert.NoError(t, err)
+	create := stmt.AST.(*ast.CreateConstraint)
+	assert.NotNil(t, create.Name)
+	assert.Equal(t, ast.UniqueConstraint, create.Constraint.Type)
+	assert.Len(t, create.Constraint.Properties, 2)
+
+	s = scanner.New([]byte("DROP INDEX ON :Person(name)"), reporter)
+	p = New(s, reporter)
+	stmt, err = p.Parse()
+	assert.NoError(t, err)
+	drop := stmt.AST.(*ast.DropIndex)
+	assert.Nil(t, drop.Name)
+	assert.Nil(t, drop.Index.Variable)
+	assert.Len(t, drop.Index.Properties, 1)
+
+	s = scanner.New([]byte("CREATE (n:Person {name: 'Index'})"), reporter)
+	p = New(s, reporter)
+	stmt, err = p.Parse()
+	assert.NoError(t, err)
+	assert.IsType(t, &ast.SinglePartQuery{}, stmt.AST)
+}
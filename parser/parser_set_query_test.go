@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/set
+
+// set/Set1.feature, set/Set2.feature, set/Set3.feature, set/Set4.feature, set/Set5.feature
+func TestSet(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (n:A) SET n.name = 'neo4j' RETURN n":               {"MATCH (n:A) SET n.name = 'neo4j' RETURN n", true},
+		"MATCH (n:A) SET (n).name = 'neo4j' RETURN n":             {"MATCH (n:A) SET (n).name = 'neo4j' RETURN n", true},
+		"MATCH ()-[r:REL]->() SET (r).name = 'neo4j' RETURN r":    {"MATCH ()-[r:REL]->() SET (r).name = 'neo4j' RETURN r", true},
+		"MATCH (n) SET n.name = 'A', n.name2 = 'B', n.num = 5":    {"MATCH (n) SET n.name = 'A', n.name2 = 'B', n.num = 5", true},
+		"MATCH (a) SET a.numbers = a.numbers + [4, 5] RETURN a":   {"MATCH (a) SET a.numbers = a.numbers + [4, 5] RETURN a.numbers", true},
+		"MATCH (a) SET a.name = missing RETURN a":                 {"MATCH (a) SET a.name = missing RETURN a", true},
+		"MATCH (n:X) SET n.name = null":                           {"MATCH (n:X) SET n.name = null", true},
+		"MATCH (n) SET n = {name: 'A', name2: 'B', num: 5}":       {"MATCH (n) SET n = {name: 'A', name2: 'B', num: 5}", true},
+		"MATCH (n) SET n = { } RETURN n":                          {"MATCH (n) SET n = { } RETURN n", true},
+		"MATCH (n) SET n += {name2: 'B'} RETURN n":                {"MATCH (n) SET n += {name2: 'B'} RETURN n", true},
+		"MATCH (n) SET n += { } RETURN n":                         {"MATCH (n) SET n += { } RETURN n", true},
+		"MATCH (n) SET n:Foo RETURN labels(n)":                    {"MATCH (n) SET n:Foo RETURN labels(n)", true},
+		"MATCH (n) SET n:Foo:Bar RETURN labels(n)":                {"MATCH (n) SET n:Foo:Bar RETURN labels(n)", true},
+		"MATCH (n) SET n :Foo :Bar RETURN labels(n)":              {"MATCH (n) SET n :Foo :Bar RETURN labels(n)", true},
+		"MATCH (n) SET n:Foo, n.num = 1, n += {a: 1}, n = {b: 2}": {"MATCH (n) SET n:Foo, n.num = 1, n += {a: 1}, n = {b: 2}", true},
+		"MATCH (n) SET n.num = 1 SET n.num = 2":                   {"MATCH (n) SET n.num = 1 SET n.num = 2", true},
+		"MATCH (n) SET n RETURN n":                                {"MATCH (n) SET n RETURN n", false},
+		"MATCH (n) SET n.num RETURN n":                            {"MATCH (n) SET n.num RETURN n", false},
+		"MATCH (n) SET RETURN n":                                  {"MATCH (n) SET RETURN n", false},
+		"MATCH (n) SET n.num = 1, RETURN n":                       {"MATCH (n) SET n.num = 1, RETURN n", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestSetItemTypes(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH (n) SET n.num = 1, n = {a: 1}, n += {b: 2}, n:Foo:Bar"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	assert.Len(t, query.UpdatingClause, 1)
+	set := query.UpdatingClause[0].(*ast.SetClause)
+	assert.Len(t, set.Items, 4)
+	assert.IsType(t, &ast.PropertySetItem{}, set.Items[0])
+	assert.IsType(t, &ast.VariableSetItem{}, set.Items[1])
+	assert.IsType(t, &ast.VariableAddSetItem{}, set.Items[2])
+	assert.IsType(t, &ast.LabelsSetItem{}, set.Items[3])
+	assert.Len(t, set.Items[3].(*ast.LabelsSetItem).Labels, 2)
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestShortestPath(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH p = shortestPath((a)-[*]-(b)) RETURN p":               {"MATCH p = shortestPath((a)-[*]-(b)) RETURN p", true},
+		"MATCH p = allShortestPaths((a)-[:KNOWS*..5]->(b)) RETURN p": {"MATCH p = allShortestPaths((a)-[:KNOWS*..5]->(b)) RETURN p", true},
+		"MATCH (a), (b) RETURN shortestPath((a)-[*0..3]-(b))":        {"MATCH (a), (b) RETURN shortestPath((a)-[*0..3]-(b))", true},
+		"MATCH (a), (b) WITH allShortestPaths((a)-->(b)) AS paths":   {"MATCH (a), (b) WITH allShortestPaths((a)-->(b)) AS paths RETURN paths", true},
+		"MATCH shortestPath((a)-[*1..]-(b)) RETURN a":                {"MATCH shortestPath((a)-[*1..]-(b)) RETURN a", true},
+		"WITH 1 AS shortestPath RETURN shortestPath":                 {"WITH 1 AS shortestPath RETURN shortestPath", true},
+		"MATCH p = shortestPath((a)) RETURN p":                       {"MATCH p = shortestPath((a)) RETURN p", false},
+		"MATCH p = shortestPath((a)-->(b)-->(c)) RETURN p":           {"MATCH p = shortestPath((a)-->(b)-->(c)) RETURN p", false},
+		"MATCH p = shortestPath((a)-[*2..5]-(b)) RETURN p":           {"MATCH p = shortestPath((a)-[*2..5]-(b)) RETURN p", false},
+		"MATCH p = shortestPath((a)-[*]-(b) RETURN p":                {"MATCH p = shortestPath((a)-[*]-(b) RETURN p", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+func TestShortestPathPattern(t *testing.T) {
+	reporter := newTestReporter()
+	s := scanner.New([]byte("MATCH p = allShortestPaths((a)-[*]-(b)) RETURN shortestPath((a)-->(b))"), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+	query := tree.(*ast.SinglePartQuery)
+	match := query.ReadingClause[0].(*ast.MatchClause)
+	pattern := match.Pattern.Parts[0].Element.(*ast.ShortestPathPattern)
+	assert.True(t, pattern.All)
+	assert.IsType(t, &ast.PatternElementPattern{}, pattern.Element)
+
+	item := query.Projection.Items.Items[0]
+	assert.IsType(t, &ast.PropertyLabelsExpr{}, item.Expr)
+	assert.IsType(t, &ast.ShortestPathPattern{}, item.Expr.(*ast.PropertyLabelsExpr).Atom)
+}
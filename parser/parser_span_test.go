@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"github.com/mburbidg/cypher/ast"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSpans(t *testing.T) {
+	src := "MATCH (n:Person)-[r:KNOWS]->(m)\nWHERE n.age > 30\nRETURN m.name AS name"
+	reporter := newTestReporter()
+	s := scanner.New([]byte(src), reporter)
+	p := New(s, reporter)
+	tree, err := p.query()
+	assert.NoError(t, err)
+
+	text := func(node ast.Node) string {
+		return src[node.Span().Start.Offset:node.Span().End.Offset]
+	}
+	query := tree.(*ast.SinglePartQuery)
+	assert.Equal(t, src, text(query))
+
+	match := query.ReadingClause[0].(*ast.MatchClause)
+	assert.Equal(t, "MATCH (n:Person)-[r:KNOWS]->(m)\nWHERE n.age > 30", text(match))
+	element := match.Pattern.Parts[0].Element.(*ast.PatternElementPattern)
+	assert.Equal(t, "(n:Person)-[r:KNOWS]->(m)", text(element))
+	assert.Equal(t, "(n:Person)", text(element.Left))
+	assert.Equal(t, "Person", text(element.Left.Labels[0]))
+	assert.Equal(t, "-[r:KNOWS]->", text(element.Chain[0].RelationshipPattern))
+	assert.Equal(t, "[r:KNOWS]", text(element.Chain[0].RelationshipPattern.RelationshipDetail))
+
+	where := match.WhereExpr.(*ast.BinaryExpr)
+	assert.Equal(t, "n.age > 30", text(where))
+	assert.Equal(t, "30", text(where.Right))
+	assert.Equal(t, scanner.Span{
+		Start: scanner.Pos{Offset: 38, Line: 2, Column: 7},
+		End:   scanner.Pos{Offset: 48, Line: 2, Column: 17},
+	}, where.Span())
+
+	item := query.Projection.Items.Items[0]
+	assert.Equal(t, "RETURN m.name AS name", text(query.Projection))
+	assert.Equal(t, "m.name AS name", text(item))
+	assert.Equal(t, "m.name", text(item.Expr))
+	assert.Equal(t, "name", text(item.Variable))
+}
+
+func TestExprSpans(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		text string
+	}{
+		"function":      {"f(x, 1) + 2", "f(x, 1)"},
+		"list":          {"[1, 2] + 2", "[1, 2]"},
+		"map":           {"{a: 1} + 2", "{a: 1}"},
+		"case":          {"CASE x WHEN 1 THEN 2 END + 2", "CASE x WHEN 1 THEN 2 END"},
+		"parameter":     {"$p + 2", "$p"},
+		"negation":      {"-x + 2", "-x"},
+		"comprehension": {"[x IN l WHERE x > 1 | x] + 2", "[x IN l WHERE x > 1 | x]"},
+		"exists":        {"EXISTS { (n)-->() } + 2", "EXISTS { (n)-->() }"},
+		"projection":    {"n {.a, b: 1} + 2", "n {.a, b: 1}"},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := scanner.New([]byte(tc.src), reporter)
+			p := New(s, reporter)
+			tree, err := p.expr()
+			assert.NoError(t, err)
+			left := tree.(*ast.BinaryExpr).Left
+			assert.Equal(t, tc.text, tc.src[left.Span().Start.Offset:left.Span().End.Offset])
+		})
+	}
+}
+
+func TestTrailingTriviaSpans(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		text string
+	}{
+		"whitespace": {"MATCH (n) RETURN n   \n\t", "MATCH (n) RETURN n"},
+		"comment":    {"MATCH (n) RETURN n   // c", "MATCH (n) RETURN n"},
+		"block":      {"MATCH (n) RETURN n.x /* c */ ", "MATCH (n) RETURN n.x"},
+		"clause":     {"MATCH (n) DELETE n // c\n", "MATCH (n) DELETE n"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			reporter := newTestReporter()
+			p := New(scanner.New([]byte(tc.src), reporter), reporter)
+			tree, err := p.query()
+			assert.NoError(t, err)
+			assert.Equal(t, tc.text, tc.src[:tree.Span().End.Offset])
+			// No node ends in the trivia following the last token.
+			ast.Inspect(tree, func(node ast.Node) bool {
+				if node != nil {
+					assert.LessOrEqual(t, node.Span().End.Offset, len(tc.text), "%T", node)
+				}
+				return true
+			})
+		})
+	}
+}
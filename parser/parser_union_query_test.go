@@ -0,0 +1,33 @@
+package parser
+
+import (
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/union
+
+// union/Union1.feature, union/Union2.feature, union/Union3.feature
+func TestUnion(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"RETURN 1 AS x UNION RETURN 2 AS x":                         {"RETURN 1 AS x UNION RETURN 2 AS x", true},
+		"RETURN 2 AS x UNION RETURN 1 AS x UNION RETURN 2 AS x":     {"RETURN 2 AS x UNION RETURN 1 AS x UNION RETURN 2 AS x", true},
+		"MATCH (a:A) RETURN a AS a UNION MATCH (b:B) RETURN b AS a": {"MATCH (a:A) RETURN a AS a UNION MATCH (b:B) RETURN b AS a", true},
+		"RETURN 1 AS a UNION RETURN 2 AS b":                         {"RETURN 1 AS a UNION RETURN 2 AS b", true},
+		"RETURN 1 AS x UNION ALL RETURN 2 AS x":                     {"RETURN 1 AS x UNION ALL RETURN 2 AS x", true},
+		"RETURN 2 AS x UNION ALL RETURN 1 AS x UNION ALL RETURN 2":  {"RETURN 2 AS x UNION ALL RETURN 1 AS x UNION ALL RETURN 2 AS x", true},
+		"RETURN 1 AS a UNION RETURN 2 AS a UNION ALL RETURN 3 AS a": {"RETURN 1 AS a UNION RETURN 2 AS a UNION ALL RETURN 3 AS a", true},
+		"MATCH (a) WITH a RETURN a UNION MATCH (b) RETURN b AS a":   {"MATCH (a) WITH a RETURN a UNION MATCH (b) RETURN b AS a", true},
+		"RETURN 1 AS x UNION":                                       {"RETURN 1 AS x UNION", false},
+		"RETURN 1 AS x UNION ALL":                                   {"RETURN 1 AS x UNION ALL", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
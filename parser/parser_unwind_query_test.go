@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/unwind
+
+// unwind/Unwind1.feature
+func TestUnwind(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"UNWIND [1, 2, 3] AS x RETURN x":                                         {"UNWIND [1, 2, 3] AS x RETURN x", true},
+		"UNWIND range(1, 3) AS x RETURN x":                                       {"UNWIND range(1, 3) AS x RETURN x", true},
+		"WITH [1, 2, 3] AS first, [4, 5, 6] AS second UNWIND (first + second)":   {"WITH [1, 2, 3] AS first, [4, 5, 6] AS second UNWIND (first + second) AS x RETURN x", true},
+		"UNWIND RANGE(1, 2) AS row WITH collect(row) AS rows UNWIND rows AS x":   {"UNWIND RANGE(1, 2) AS row WITH collect(row) AS rows UNWIND rows AS x RETURN x", true},
+		"MATCH (row) WITH collect(row) AS rows UNWIND rows AS node":              {"MATCH (row) WITH collect(row) AS rows UNWIND rows AS node RETURN node.id", true},
+		"UNWIND $events AS event MATCH (y:Year {year: event.year})":              {"UNWIND $events AS event MATCH (y:Year {year: event.year}) RETURN y", true},
+		"WITH [[1, 2, 3], [4, 5, 6]] AS lol UNWIND lol AS x UNWIND x AS y":       {"WITH [[1, 2, 3], [4, 5, 6]] AS lol UNWIND lol AS x UNWIND x AS y RETURN y", true},
+		"UNWIND [] AS empty RETURN empty":                                        {"UNWIND [] AS empty RETURN empty", true},
+		"UNWIND null AS nil RETURN nil":                                          {"UNWIND null AS nil RETURN nil", true},
+		"WITH [1, 2, 3] AS list UNWIND list AS x RETURN *":                       {"WITH [1, 2, 3] AS list UNWIND list AS x RETURN *", true},
+		"MATCH (a:S)-[:X]->(b1) WITH a, collect(b1) AS bees UNWIND bees AS b2":   {"MATCH (a:S)-[:X]->(b1) WITH a, collect(b1) AS bees UNWIND bees AS b2 MATCH (a)-[:Y]->(b2) RETURN a, b2", true},
+		"WITH [1, 2] AS xs, [3, 4] AS ys UNWIND xs AS x UNWIND ys AS y RETURN *": {"WITH [1, 2] AS xs, [3, 4] AS ys UNWIND xs AS x UNWIND ys AS y RETURN *", true},
+		"UNWIND [1, 2, 3] RETURN x":                                              {"UNWIND [1, 2, 3] RETURN x", false},
+		"UNWIND [1, 2, 3] AS RETURN x":                                           {"UNWIND [1, 2, 3] AS RETURN x", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
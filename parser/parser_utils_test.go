@@ -1,11 +1,42 @@
 package parser
 
 import (
+	"github.com/mburbidg/cypher/ast"
 	"github.com/mburbidg/cypher/scanner"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
 )
 
+var nodeSpanType = reflect.TypeOf(ast.NodeSpan{})
+
+// clearSpans zeroes the spans of node and everything below it, so trees can be compared without positions.
+func clearSpans(node any) {
+	clearValueSpans(reflect.ValueOf(node))
+}
+
+func clearValueSpans(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			clearValueSpans(v.Elem())
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			clearValueSpans(v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if field.Type() == nodeSpanType {
+				field.Set(reflect.Zero(nodeSpanType))
+			} else if field.CanSet() {
+				clearValueSpans(field)
+			}
+		}
+	}
+}
+
 func runExprTest(t *testing.T, reporter *testReporter, tc struct {
 	src   string
 	valid bool
@@ -27,7 +58,7 @@ func runQueryTest(t *testing.T, reporter *testReporter, tc struct {
 }) {
 	s := scanner.New([]byte(tc.src), reporter)
 	p := New(s, reporter)
-	tree, err := p.singlePartQuery()
+	tree, err := p.query()
 	if tc.valid {
 		assert.NoError(t, err)
 		assert.NotNil(t, tree)
@@ -35,3 +66,18 @@ func runQueryTest(t *testing.T, reporter *testReporter, tc struct {
 		assert.Error(t, err)
 	}
 }
+
+func runStatementTest(t *testing.T, reporter *testReporter, tc struct {
+	src   string
+	valid bool
+}) {
+	s := scanner.New([]byte(tc.src), reporter)
+	p := New(s, reporter)
+	stmt, err := p.Parse()
+	if tc.valid {
+		assert.NoError(t, err)
+		assert.NotNil(t, stmt.AST)
+	} else {
+		assert.Error(t, err)
+	}
+}
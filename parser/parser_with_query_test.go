@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"testing"
+)
+
+// We test the parser against the tkl use cases, for queries.
+// https://github.com/opencypher/openCypher/tree/master/tck/features/clauses/with
+
+// with/With1.feature
+func TestForwardingVariables(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"MATCH (a:A) WITH a MATCH (a)-->(b) RETURN *":                   {"MATCH (a:A) WITH a MATCH (a)-->(b) RETURN *", true},
+		"MATCH (a:A) WITH a MATCH (x:X), (a)-->(b) RETURN *":            {"MATCH (a:A) WITH a MATCH (x:X), (a)-->(b) RETURN *", true},
+		"MATCH ()-[r1]->(:X) WITH r1 AS r2 MATCH ()-[r2]->() RETURN r2": {"MATCH ()-[r1]->(:X) WITH r1 AS r2 MATCH ()-[r2]->() RETURN r2 AS rel", true},
+		"MATCH p = (a) WITH p RETURN p":                                 {"MATCH p = (a) WITH p RETURN p", true},
+		"OPTIONAL MATCH (a:Start) WITH a MATCH (a)-->(b) RETURN *":      {"OPTIONAL MATCH (a:Start) WITH a MATCH (a)-->(b) RETURN *", true},
+		"MATCH (a) WITH":   {"MATCH (a) WITH", false},
+		"MATCH (a) WITH a": {"MATCH (a) WITH a", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+// with/With2.feature, with/With3.feature, with/With4.feature
+func TestForwardingExpressions(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"WITH {name: {name2: 'baz'}} AS nestedMap RETURN nestedMap.name.name2":     {"WITH {name: {name2: 'baz'}} AS nestedMap RETURN nestedMap.name.name2", true},
+		"MATCH (a:Begin) WITH a.num AS property MATCH (b) WHERE b.id = property":   {"MATCH (a:Begin) WITH a.num AS property MATCH (b) WHERE b.id = property RETURN b", true},
+		"MATCH (n) WITH n.name AS n RETURN n":                                      {"MATCH (n) WITH n.name AS n RETURN n", true},
+		"WITH 1 AS a, 2 AS a RETURN a":                                             {"WITH 1 AS a, 2 AS a RETURN a", true},
+		"MATCH (a)-[r]->(b:X) WITH a, r, b MATCH (a)-[r]->(b) RETURN r ORDER BY r": {"MATCH (a)-[r]->(b:X) WITH a, r, b MATCH (a)-[r]->(b) RETURN r AS rel ORDER BY rel.id", true},
+		"CREATE (a:A) WITH a MATCH (b) RETURN b":                                   {"CREATE (a:A) WITH a MATCH (b) RETURN b", true},
+		"CREATE (a:A) WITH a CREATE (b:B)":                                         {"CREATE (a:A) WITH a CREATE (b:B)", true},
+		"MATCH (a) WITH a, 1 AS x WITH a, x, 2 AS y RETURN a, x, y":                {"MATCH (a) WITH a, 1 AS x WITH a, x, 2 AS y RETURN a, x, y", true},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
+
+// with-orderBy, with-skip-limit, with-where
+func TestWithProjectionBody(t *testing.T) {
+	tests := map[string]struct {
+		src   string
+		valid bool
+	}{
+		"WITH DISTINCT":         {"MATCH (a) WITH DISTINCT a.name AS name RETURN name", true},
+		"WITH ORDER BY":         {"MATCH (a) WITH a ORDER BY a.name DESC RETURN a", true},
+		"WITH ORDER BY LIMIT":   {"MATCH (a) WITH a ORDER BY a.name LIMIT 1 RETURN a", true},
+		"WITH SKIP":             {"MATCH (a) WITH a SKIP 2 RETURN a", true},
+		"WITH SKIP LIMIT":       {"MATCH (a) WITH a SKIP 2 LIMIT 1 RETURN a", true},
+		"WITH WHERE":            {"MATCH (a) WITH a WHERE a.name = 'B' RETURN a", true},
+		"WITH ORDER BY WHERE":   {"MATCH (a) WITH a ORDER BY a.name LIMIT 1 WHERE a.num > 1 RETURN a", true},
+		"WITH * WHERE":          {"MATCH (a)-->(b) WITH * WHERE b:B RETURN a", true},
+		"WITH ORDER BY missing": {"MATCH (a) WITH a ORDER BY RETURN a", false},
+	}
+	reporter := newTestReporter()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			runQueryTest(t, reporter, tc)
+		})
+	}
+}
@@ -11,11 +11,20 @@ type tokenInfo struct {
 
 func addReservedWord(t tokenInfo) tokenInfo {
 	ReservedWordTokens[t.t] = true
+	reservedWordLexemes[t.t] = t.lexeme
 	return t
 }
 
 var ReservedWordTokens = map[TokenType]bool{}
 
+var reservedWordLexemes = map[TokenType]string{}
+
+// ReservedWord returns the upper case spelling of the reserved word with token type t.
+func ReservedWord(t TokenType) (string, bool) {
+	lexeme, ok := reservedWordLexemes[t]
+	return lexeme, ok
+}
+
 var reservedWords = tokenMap{
 	"all":        addReservedWord(tokenInfo{All, "ALL"}),
 	"asc":        addReservedWord(tokenInfo{Asc, "ASC"}),
@@ -23,7 +32,7 @@ var reservedWords = tokenMap{
 	"by":         addReservedWord(tokenInfo{By, "BY"}),
 	"create":     addReservedWord(tokenInfo{Create, "CREATE"}),
 	"delete":     addReservedWord(tokenInfo{Delete, "DELETE"}),
-	"desc":       addReservedWord(tokenInfo{Desc, "DECS"}),
+	"desc":       addReservedWord(tokenInfo{Desc, "DESC"}),
 	"descending": addReservedWord(tokenInfo{Descending, "DESCENDING"}),
 	"detach":     addReservedWord(tokenInfo{Detach, "DETACH"}),
 	"exists":     addReservedWord(tokenInfo{Exists, "EXISTS"}),
@@ -19,6 +19,15 @@ func TestReservedWordToken(t *testing.T) {
 	assert.Equal(t, 20, token.Line)
 }
 
+func TestReservedWord(t *testing.T) {
+	lexeme, ok := ReservedWord(Desc)
+	assert.True(t, ok)
+	assert.Equal(t, "DESC", lexeme)
+
+	_, ok = ReservedWord(Identifier)
+	assert.False(t, ok)
+}
+
 func TestNotReservedWordToken(t *testing.T) {
 	_, ok := reservedWords.token("creat", 10)
 	assert.False(t, ok)
@@ -10,16 +10,26 @@ import (
 )
 
 type Position struct {
-	offset     int
-	prevOffset int
-	line       int
-	eofRead    bool
+	offset        int
+	prevOffset    int
+	line          int
+	prevLine      int
+	lineStart     int
+	prevLineStart int
+	eofRead       bool
 }
 
 type Scanner struct {
 	src      []byte
 	Position Position
 	reporter utils.Reporter
+
+	// The start of the token being scanned.
+	tokenStart Pos
+
+	// Whether trivia is recorded, and the trivia preceding the token being scanned.
+	lossless bool
+	trivia   []Trivia
 }
 
 const (
@@ -29,11 +39,20 @@ const (
 func New(src []byte, reporter utils.Reporter) *Scanner {
 	return &Scanner{
 		src:      src,
-		Position: Position{offset: 0, prevOffset: 0, line: 1, eofRead: false},
+		Position: Position{offset: 0, prevOffset: 0, line: 1, prevLine: 1, eofRead: false},
 		reporter: reporter,
 	}
 }
 
+// NewLossless returns a scanner that attaches the whitespace and comments preceding each token to it, as
+// its trivia. The source is reproduced exactly by the trivia and text of the tokens up to and including
+// EndOfInput.
+func NewLossless(src []byte, reporter utils.Reporter) *Scanner {
+	s := New(src, reporter)
+	s.lossless = true
+	return s
+}
+
 func (s *Scanner) String() string {
 	return string(s.src)
 }
@@ -42,9 +61,12 @@ func (s *Scanner) next() rune {
 	if s.Position.offset < len(s.src) {
 		r, w := utf8.DecodeRune(s.src[s.Position.offset:])
 		s.Position.prevOffset = s.Position.offset
+		s.Position.prevLine = s.Position.line
+		s.Position.prevLineStart = s.Position.lineStart
 		s.Position.offset += w
 		if r == '\n' {
 			s.Position.line += 1
+			s.Position.lineStart = s.Position.offset
 		}
 		return r
 	}
@@ -64,6 +86,8 @@ func (s *Scanner) prev() {
 	// Once we hit eof, no backing up.
 	if !s.Position.eofRead {
 		s.Position.offset = s.Position.prevOffset
+		s.Position.line = s.Position.prevLine
+		s.Position.lineStart = s.Position.prevLineStart
 	}
 }
 
@@ -71,7 +95,39 @@ func (s *Scanner) Line() int {
 	return s.Position.line
 }
 
+// Pos returns the current position, which is the end of the last token scanned.
+func (s *Scanner) Pos() Pos {
+	return Pos{Offset: s.Position.offset, Line: s.Position.line, Column: s.Position.offset - s.Position.lineStart + 1}
+}
+
 func (s *Scanner) NextToken() Token {
+	s.trivia = nil
+	t := s.scanToken()
+	t.Span = Span{Start: s.tokenStart, End: s.Pos()}
+	t.Trivia = s.trivia
+	return t
+}
+
+// Text returns the source text of a token scanned by s.
+func (s *Scanner) Text(t Token) string {
+	return string(s.src[t.Span.Start.Offset:t.Span.End.Offset])
+}
+
+// addTrivia records the trivia scanned since the start of the current token, if the scanner is lossless.
+func (s *Scanner) addTrivia(kind TriviaKind) {
+	if !s.lossless {
+		return
+	}
+	span := Span{Start: s.tokenStart, End: s.Pos()}
+	s.trivia = append(s.trivia, Trivia{
+		Kind: kind,
+		Text: string(s.src[span.Start.Offset:span.End.Offset]),
+		Span: span,
+	})
+}
+
+func (s *Scanner) scanToken() Token {
+	s.tokenStart = s.Pos()
 	if s.Position.offset >= len(s.src) {
 		return endOfInputToken
 	}
@@ -109,6 +165,11 @@ func (s *Scanner) NextToken() Token {
 	case ch == ']':
 		return newOperatorToken(CloseBracket, s.Position.line)
 	case ch == '+':
+		ch := s.next()
+		if ch == '=' {
+			return newOperatorToken(PlusEqual, s.Position.line)
+		}
+		s.prev()
 		return newOperatorToken(Plus, s.Position.line)
 	case ch == '-':
 		return newOperatorToken(Dash, s.Position.line)
@@ -119,10 +180,12 @@ func (s *Scanner) NextToken() Token {
 		switch ch {
 		case '*':
 			s.consumeMultilineComment()
-			return s.NextToken()
+			s.addTrivia(BlockComment)
+			return s.scanToken()
 		case '/':
 			s.consumeSingleLineComment()
-			return s.NextToken()
+			s.addTrivia(LineComment)
+			return s.scanToken()
 		default:
 			s.prev()
 			return newOperatorToken(ForwardSlash, s.Position.line)
@@ -154,9 +217,17 @@ func (s *Scanner) NextToken() Token {
 	case ch == '$':
 		return newOperatorToken(DollarSign, s.Position.line)
 	case ch == ':':
+		if s.next() == ':' {
+			return newOperatorToken(DoubleColon, s.Position.line)
+		}
+		s.prev()
 		return newOperatorToken(Colon, s.Position.line)
 	case ch == '|':
 		return newOperatorToken(Pipe, s.Position.line)
+	case ch == '&':
+		return newOperatorToken(Ampersand, s.Position.line)
+	case ch == '!':
+		return newOperatorToken(Exclamation, s.Position.line)
 	case unicode.IsDigit(ch):
 		return s.scanNumber(ch)
 	case ch == '"', ch == '\'':
@@ -165,7 +236,8 @@ func (s *Scanner) NextToken() Token {
 		return s.scanIdentifier(ch)
 	case isSpace(ch):
 		s.consumeWhitespace(ch)
-		return s.NextToken()
+		s.addTrivia(Whitespace)
+		return s.scanToken()
 	}
 
 	return Token{}
@@ -3,6 +3,7 @@ package scanner
 import (
 	"github.com/mburbidg/cypher/utils"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -138,6 +139,12 @@ func TestPunctuation(t *testing.T) {
 		"greaterthan/ws":       {"a > b", []TokenType{Identifier, GreaterThan, Identifier, EndOfInput}},
 		"greaterthanorequal":   {"a>=b", []TokenType{Identifier, GreaterThanOrEqual, Identifier, EndOfInput}},
 		"greaterhanorequal/ws": {"a >= b", []TokenType{Identifier, GreaterThanOrEqual, Identifier, EndOfInput}},
+		"plusequal":            {"a+=b", []TokenType{Identifier, PlusEqual, Identifier, EndOfInput}},
+		"plusequal/ws":         {"a += b", []TokenType{Identifier, PlusEqual, Identifier, EndOfInput}},
+		"plus equal":           {"a + = b", []TokenType{Identifier, Plus, Equal, Identifier, EndOfInput}},
+		"label expression":     {"A&!B|%", []TokenType{Identifier, Ampersand, Exclamation, Identifier, Pipe, Percent, EndOfInput}},
+		"type predicate":       {"x::INTEGER", []TokenType{Identifier, DoubleColon, Identifier, EndOfInput}},
+		"colon":                {"n:A", []TokenType{Identifier, Colon, Identifier, EndOfInput}},
 		"illegal character":    {"a—b", []TokenType{Identifier, Illegal, Identifier, EndOfInput}},
 	}
 
@@ -238,3 +245,67 @@ func assertLiteral(t *testing.T, expected []interface{}, scanner *Scanner) {
 		assert.Equal(t, literal, token.Literal)
 	}
 }
+
+func TestSpans(t *testing.T) {
+	s := New([]byte("MATCH (n)\n  // comment\n  RETURN n.name,\n'a\nb'"), newTestReporter())
+	tests := []struct {
+		t    TokenType
+		span Span
+	}{
+		{Match, Span{Pos{0, 1, 1}, Pos{5, 1, 6}}},
+		{OpenParen, Span{Pos{6, 1, 7}, Pos{7, 1, 8}}},
+		{Identifier, Span{Pos{7, 1, 8}, Pos{8, 1, 9}}},
+		{CloseParen, Span{Pos{8, 1, 9}, Pos{9, 1, 10}}},
+		{Return, Span{Pos{25, 3, 3}, Pos{31, 3, 9}}},
+		{Identifier, Span{Pos{32, 3, 10}, Pos{33, 3, 11}}},
+		{Period, Span{Pos{33, 3, 11}, Pos{34, 3, 12}}},
+		{Identifier, Span{Pos{34, 3, 12}, Pos{38, 3, 16}}},
+		{Comma, Span{Pos{38, 3, 16}, Pos{39, 3, 17}}},
+		{String, Span{Pos{40, 4, 1}, Pos{45, 5, 3}}},
+		{EndOfInput, Span{Pos{45, 5, 3}, Pos{45, 5, 3}}},
+	}
+	for _, tc := range tests {
+		token := s.NextToken()
+		assert.Equal(t, tc.t, token.T)
+		assert.Equal(t, tc.span, token.Span)
+	}
+}
+
+func TestTrivia(t *testing.T) {
+	src := "MATCH (n) // find\n  /* all */ RETURN n \n"
+	s := NewLossless([]byte(src), newTestReporter())
+	tests := []struct {
+		t      TokenType
+		trivia []Trivia
+	}{
+		{Match, nil},
+		{OpenParen, []Trivia{{Whitespace, " ", Span{Pos{5, 1, 6}, Pos{6, 1, 7}}}}},
+		{Identifier, nil},
+		{CloseParen, nil},
+		{Return, []Trivia{
+			{Whitespace, " ", Span{Pos{9, 1, 10}, Pos{10, 1, 11}}},
+			{LineComment, "// find\n", Span{Pos{10, 1, 11}, Pos{18, 2, 1}}},
+			{Whitespace, "  ", Span{Pos{18, 2, 1}, Pos{20, 2, 3}}},
+			{BlockComment, "/* all */", Span{Pos{20, 2, 3}, Pos{29, 2, 12}}},
+			{Whitespace, " ", Span{Pos{29, 2, 12}, Pos{30, 2, 13}}},
+		}},
+		{Identifier, []Trivia{{Whitespace, " ", Span{Pos{36, 2, 19}, Pos{37, 2, 20}}}}},
+		{EndOfInput, []Trivia{{Whitespace, " \n", Span{Pos{38, 2, 21}, Pos{40, 3, 1}}}}},
+	}
+	b := strings.Builder{}
+	for _, tc := range tests {
+		token := s.NextToken()
+		assert.Equal(t, tc.t, token.T)
+		assert.Equal(t, tc.trivia, token.Trivia)
+		for _, trivia := range token.Trivia {
+			b.WriteString(trivia.Text)
+		}
+		b.WriteString(s.Text(token))
+	}
+	assert.Equal(t, src, b.String())
+
+	s = New([]byte(src), newTestReporter())
+	for token := s.NextToken(); token.T != EndOfInput; token = s.NextToken() {
+		assert.Nil(t, token.Trivia)
+	}
+}
@@ -0,0 +1,15 @@
+package scanner
+
+// Pos is a location in the source: a byte offset, and the line and column of that offset. Lines and columns
+// start at 1, and columns count bytes.
+type Pos struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// Span is the source range [Start, End) that a token or AST node was read from.
+type Span struct {
+	Start Pos
+	End   Pos
+}
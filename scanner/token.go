@@ -9,6 +9,10 @@ type Token struct {
 	Lexeme  string
 	Literal any
 	Line    int
+	Span    Span
+
+	// The whitespace and comments preceding the token. It is only recorded by a lossless scanner.
+	Trivia []Trivia
 }
 
 var endOfInputToken = Token{
@@ -33,6 +33,10 @@ const (
 	DollarSign
 	Colon
 	Pipe
+	PlusEqual
+	Ampersand
+	Exclamation
+	DoubleColon
 
 	Identifier
 	Double
@@ -0,0 +1,20 @@
+package scanner
+
+type TriviaKind int
+
+const (
+	Whitespace TriviaKind = iota
+	LineComment
+	BlockComment
+)
+
+// Trivia is source text between tokens that the parser ignores: whitespace and comments. A lossless
+// scanner attaches it to the token that follows it.
+type Trivia struct {
+	Kind TriviaKind
+
+	// The text of the trivia, exactly as it is in the source. The text of a line comment includes the
+	// newline ending it.
+	Text string
+	Span Span
+}
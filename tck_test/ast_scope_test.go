@@ -0,0 +1,58 @@
+package tck_test
+
+import (
+	"github.com/mburbidg/cypher"
+	"github.com/mburbidg/cypher/parser"
+	"github.com/mburbidg/cypher/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestScopes checks the variables visible to each part of a query, for cases the TCK does not cover. An empty
+// code means the query is valid.
+func TestScopes(t *testing.T) {
+	tests := map[string]struct {
+		src  string
+		code string
+	}{
+		"WITH projects variables":      {"MATCH (a), (b) WITH a RETURN a", ""},
+		"WITH projects aliases":        {"MATCH (a) WITH a.x AS x RETURN x", ""},
+		"WITH * projects everything":   {"MATCH (a), (b) WITH *, 1 AS c RETURN a, b, c", ""},
+		"WITH hides other variables":   {"MATCH (a), (b) WITH a RETURN b", cypher.UndefinedVariable},
+		"WITH hides aliased variables": {"MATCH (a) WITH a AS b RETURN a", cypher.UndefinedVariable},
+		"UNION arms see the enclosing scope": {
+			"MATCH (n), (k) WHERE EXISTS { MATCH (n) RETURN n AS x UNION MATCH (k) RETURN k AS x } RETURN k", "",
+		},
+		"UNION arms do not see each other":     {"MATCH (a) RETURN a AS x UNION RETURN a AS x", cypher.UndefinedVariable},
+		"FOREACH variable in the body":         {"FOREACH (x IN [1, 2, 3] | CREATE ({num: x}))", ""},
+		"FOREACH list sees the scope":          {"WITH [1, 2, 3] AS l FOREACH (x IN l | CREATE ({num: x}))", ""},
+		"FOREACH variable not in its list":     {"FOREACH (x IN x | CREATE ())", cypher.UndefinedVariable},
+		"FOREACH variable not after the body":  {"FOREACH (x IN [1, 2, 3] | CREATE ()) WITH * RETURN x", cypher.UndefinedVariable},
+		"EXISTS sees the enclosing scope":      {"MATCH (n) WHERE EXISTS { MATCH (n)-->(m) } RETURN n", ""},
+		"EXISTS variables do not leak":         {"MATCH (n) WHERE EXISTS { MATCH (n)-->(m) } RETURN m", cypher.UndefinedVariable},
+		"EXISTS pattern variables do not leak": {"MATCH (n) WHERE EXISTS { (n)-->(m) } RETURN m", cypher.UndefinedVariable},
+		"CALL imports into each UNION arm": {
+			"MATCH (a) CALL { WITH a RETURN a.x AS x UNION WITH a RETURN a.y AS x } RETURN x", "",
+		},
+		"CALL imports per UNION arm": {
+			"MATCH (a), (b) CALL { WITH a RETURN a.x AS x UNION WITH b RETURN a.y AS x } RETURN x", cypher.UndefinedVariable,
+		},
+		"COUNT variables do not leak": {"MATCH (n) WHERE COUNT { MATCH (n)-->(m) } > 1 RETURN m", cypher.UndefinedVariable},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &reporter{}
+			stmt, err := parser.New(scanner.New([]byte(tc.src), r), r).Parse()
+			require.NoError(t, err)
+			err = (&astRuntime{}).eval(stmt)
+			if tc.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.IsType(t, &cypher.CypherErr{}, err) {
+				assert.Equal(t, tc.code, err.(*cypher.CypherErr).Code)
+			}
+		})
+	}
+}
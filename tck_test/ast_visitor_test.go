@@ -11,17 +11,150 @@ type astVisitor struct {
 	inPattern    bool
 	inMatch      bool
 	inCreate     bool
+	inMerge      bool
 	inNode       bool
 	inRel        bool
 	inExpr       bool
+	inSchemaName bool
 	creatingNode bool
 	hasProps     bool
 	hasLabels    bool
 	symbolTable  map[string]any
+
+	// The symbol tables of the enclosing scopes, saved while visiting a UNION, a subquery or FOREACH.
+	scopes []map[string]any
+
+	// The variables bound before each pattern being visited, which are those visible to the inline WHERE of
+	// its node and relationship patterns.
+	patternScopes []map[string]any
+
+	// The variable of the FOREACH clause being visited, until it is bound after its list has been visited.
+	foreachVariable ast.SymbolicName
+
+	// The leading WITH clauses of CALL { ... } subqueries yet to be visited, and the scope they import
+	// variables from.
+	importingWith map[*ast.WithClause]map[string]any
 }
 
+// symbolKind records what a variable is bound to, as far as it is known without evaluating the query.
+type symbolKind int
+
+const (
+	valueSymbol symbolKind = iota
+	nodeSymbol
+	relationshipSymbol
+	pathSymbol
+)
+
 func newASTVisitor() ast.Visitor {
-	return &astVisitor{symbolTable: map[string]any{}}
+	return &astVisitor{symbolTable: map[string]any{}, importingWith: map[*ast.WithClause]map[string]any{}}
+}
+
+func (visitor *astVisitor) VisitUnionQueryEnter(query *ast.UnionQuery) error {
+	log.Printf("Enter UnionQuery\n")
+	columns, known := visitor.returnColumns(query.Query)
+	for _, union := range query.Unions {
+		if union.All != query.Unions[0].All {
+			return cypher.NewInvalidClauseComposition()
+		}
+		unionColumns, unionKnown := visitor.returnColumns(union.Query)
+		if !known || !unionKnown {
+			continue
+		}
+		if len(columns) != len(unionColumns) {
+			return cypher.NewDifferentColumnsInUnion()
+		}
+		for j := range columns {
+			if columns[j] != unionColumns[j] {
+				return cypher.NewDifferentColumnsInUnion()
+			}
+		}
+	}
+	// Each arm of the union starts with the variables in scope before it, and binds its own.
+	visitor.scopes = append(visitor.scopes, visitor.symbolTable)
+	visitor.symbolTable = copyScope(visitor.symbolTable)
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnionQueryLeave(query *ast.UnionQuery) error {
+	log.Printf("Leave UnionQuery\n")
+	visitor.symbolTable = visitor.scopes[len(visitor.scopes)-1]
+	visitor.scopes = visitor.scopes[:len(visitor.scopes)-1]
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnionEnter(union *ast.Union) error {
+	log.Printf("Enter Union\n")
+	visitor.symbolTable = copyScope(visitor.scopes[len(visitor.scopes)-1])
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnionLeave(union *ast.Union) error {
+	log.Printf("Leave Union\n")
+	return nil
+}
+
+// returnColumns returns the column names of the RETURN clause of a query, or of the first arm of a union.
+// The names are only known statically when every projection item is aliased or is a bare variable.
+func (visitor *astVisitor) returnColumns(query ast.Query) ([]string, bool) {
+	var projection *ast.Projection
+	switch q := query.(type) {
+	case *ast.UnionQuery:
+		return visitor.returnColumns(q.Query)
+	case *ast.SinglePartQuery:
+		projection = q.Projection
+	case *ast.MultiPartQuery:
+		projection = q.SinglePartQuery.Projection
+	}
+	if projection == nil || projection.Items.All {
+		return nil, false
+	}
+	columns := []string{}
+	for _, item := range projection.Items.Items {
+		if item.Variable != nil {
+			columns = append(columns, symbolicNameString(item.Variable))
+			continue
+		}
+		id, ok := variableName(item.Expr)
+		if !ok {
+			return nil, false
+		}
+		columns = append(columns, id)
+	}
+	return columns, true
+}
+
+// variableName returns the name of the variable when expr is nothing more than a variable reference.
+func variableName(expr ast.Expr) (string, bool) {
+	if e, ok := expr.(*ast.PropertyLabelsExpr); ok {
+		if len(e.PropertyKeys) > 0 || len(e.Labels) > 0 || e.LabelExpr != nil {
+			return "", false
+		}
+		expr = e.Atom
+	}
+	if v, ok := expr.(*ast.VariableExpr); ok {
+		return symbolicNameString(v.SymbolicName), true
+	}
+	return "", false
+}
+
+// copyScope returns a copy of the symbol table of a scope, for a nested scope to bind variables in.
+func copyScope(symbolTable map[string]any) map[string]any {
+	scope := map[string]any{}
+	for id, kind := range symbolTable {
+		scope[id] = kind
+	}
+	return scope
+}
+
+func symbolicNameString(name ast.SymbolicName) string {
+	switch n := name.(type) {
+	case *ast.SymbolicNameIdentifier:
+		return n.Identifier.Lexeme
+	case *ast.SymbolicNameHexLetter:
+		return string(n.Letter)
+	}
+	return ""
 }
 
 func (visitor *astVisitor) VisitSinglePartQueryEnter(query *ast.SinglePartQuery) error {
@@ -34,6 +167,26 @@ func (visitor *astVisitor) VisitSinglePartQueryLeave(query *ast.SinglePartQuery)
 	return nil
 }
 
+func (visitor *astVisitor) VisitMultiPartQueryEnter(query *ast.MultiPartQuery) error {
+	log.Printf("Enter MultiPartQuery\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitMultiPartQueryLeave(query *ast.MultiPartQuery) error {
+	log.Printf("Leave MultiPartQuery\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitMultiPartQueryPartEnter(part *ast.MultiPartQueryPart) error {
+	log.Printf("Enter MultiPartQueryPart\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitMultiPartQueryPartLeave(part *ast.MultiPartQueryPart) error {
+	log.Printf("Leave MultiPartQueryPart\n")
+	return nil
+}
+
 func (visitor *astVisitor) VisitReadingClauseEnter(clause []ast.ReadingClause) error {
 	log.Printf("Enter ReadingClause\n")
 	return nil
@@ -66,6 +219,156 @@ func (visitor *astVisitor) VisitCreateLeave(clause *ast.CreateClause) error {
 	return nil
 }
 
+func (visitor *astVisitor) VisitMergeEnter(clause *ast.MergeClause) error {
+	log.Printf("Enter Merge\n")
+	visitor.inMerge = true
+	return nil
+}
+
+func (visitor *astVisitor) VisitMergeLeave(clause *ast.MergeClause) error {
+	log.Printf("Leave Merge\n")
+	visitor.inMerge = false
+	return nil
+}
+
+func (visitor *astVisitor) VisitMergeActionEnter(action *ast.MergeAction) error {
+	log.Printf("Enter MergeAction\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitMergeActionLeave(action *ast.MergeAction) error {
+	log.Printf("Leave MergeAction\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitSetEnter(clause *ast.SetClause) error {
+	log.Printf("Enter Set\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitSetLeave(clause *ast.SetClause) error {
+	log.Printf("Leave Set\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertySetItemEnter(item *ast.PropertySetItem) error {
+	log.Printf("Enter PropertySetItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertySetItemLeave(item *ast.PropertySetItem) error {
+	log.Printf("Leave PropertySetItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitVariableSetItemEnter(item *ast.VariableSetItem) error {
+	log.Printf("Enter VariableSetItem\n")
+	return visitor.expectBound(item.Variable)
+}
+
+func (visitor *astVisitor) VisitVariableSetItemLeave(item *ast.VariableSetItem) error {
+	log.Printf("Leave VariableSetItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitVariableAddSetItemEnter(item *ast.VariableAddSetItem) error {
+	log.Printf("Enter VariableAddSetItem\n")
+	return visitor.expectBound(item.Variable)
+}
+
+func (visitor *astVisitor) VisitVariableAddSetItemLeave(item *ast.VariableAddSetItem) error {
+	log.Printf("Leave VariableAddSetItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelsSetItemEnter(item *ast.LabelsSetItem) error {
+	log.Printf("Enter LabelsSetItem\n")
+	return visitor.expectBound(item.Variable)
+}
+
+func (visitor *astVisitor) VisitLabelsSetItemLeave(item *ast.LabelsSetItem) error {
+	log.Printf("Leave LabelsSetItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitRemoveEnter(clause *ast.RemoveClause) error {
+	log.Printf("Enter Remove\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitRemoveLeave(clause *ast.RemoveClause) error {
+	log.Printf("Leave Remove\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelsRemoveItemEnter(item *ast.LabelsRemoveItem) error {
+	log.Printf("Enter LabelsRemoveItem\n")
+	return visitor.expectBound(item.Variable)
+}
+
+func (visitor *astVisitor) VisitLabelsRemoveItemLeave(item *ast.LabelsRemoveItem) error {
+	log.Printf("Leave LabelsRemoveItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertyRemoveItemEnter(item *ast.PropertyRemoveItem) error {
+	log.Printf("Enter PropertyRemoveItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertyRemoveItemLeave(item *ast.PropertyRemoveItem) error {
+	log.Printf("Leave PropertyRemoveItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitDeleteEnter(clause *ast.DeleteClause) error {
+	log.Printf("Enter Delete\n")
+	for _, expr := range clause.Exprs {
+		if err := visitor.checkDeleteExpr(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (visitor *astVisitor) VisitDeleteLeave(clause *ast.DeleteClause) error {
+	log.Printf("Leave Delete\n")
+	return nil
+}
+
+// checkDeleteExpr rejects deleted expressions that are statically known not to be a node, relationship or path.
+func (visitor *astVisitor) checkDeleteExpr(expr ast.Expr) error {
+	switch e := expr.(type) {
+	case *ast.PropertyLabelsExpr:
+		if len(e.Labels) > 0 || e.LabelExpr != nil {
+			return cypher.NewInvalidDelete()
+		}
+		if len(e.PropertyKeys) > 0 {
+			return nil
+		}
+		return visitor.checkDeleteExpr(e.Atom)
+	case *ast.BinaryExpr:
+		if e.Op != ast.StringOrListOp {
+			return cypher.NewInvalidArgumentType()
+		}
+	case *ast.UnaryExpr, *ast.MapLiteral, *ast.QuantifierExpr:
+		return cypher.NewInvalidArgumentType()
+	case *ast.PrimitiveLiteral:
+		if e.Value != nil {
+			return cypher.NewInvalidArgumentType()
+		}
+	}
+	return nil
+}
+
+func (visitor *astVisitor) expectBound(name ast.SymbolicName) error {
+	id := symbolicNameString(name)
+	if _, ok := visitor.symbolTable[id]; !ok {
+		return cypher.NewUndefinedVariableErr(id)
+	}
+	return nil
+}
+
 func (visitor *astVisitor) VisitMatchEnter(clause *ast.MatchClause) error {
 	log.Printf("Enter Match\n")
 	visitor.inMatch = true
@@ -78,20 +381,307 @@ func (visitor *astVisitor) VisitMatchLeave(clause *ast.MatchClause) error {
 	return nil
 }
 
+func (visitor *astVisitor) VisitUnwindEnter(clause *ast.UnwindClause) error {
+	log.Printf("Enter Unwind\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnwindLeave(clause *ast.UnwindClause) error {
+	log.Printf("Leave Unwind\n")
+	visitor.getOrBind(symbolicNameString(clause.Variable), valueSymbol)
+	return nil
+}
+
+func (visitor *astVisitor) VisitCreateIndexEnter(command *ast.CreateIndex) error {
+	log.Printf("Enter CreateIndex\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitCreateIndexLeave(command *ast.CreateIndex) error {
+	log.Printf("Leave CreateIndex\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitDropIndexEnter(command *ast.DropIndex) error {
+	log.Printf("Enter DropIndex\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitDropIndexLeave(command *ast.DropIndex) error {
+	log.Printf("Leave DropIndex\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitIndexDefinitionEnter(def *ast.IndexDefinition) error {
+	log.Printf("Enter IndexDefinition\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitIndexDefinitionLeave(def *ast.IndexDefinition) error {
+	log.Printf("Leave IndexDefinition\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitCreateConstraintEnter(command *ast.CreateConstraint) error {
+	log.Printf("Enter CreateConstraint\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitCreateConstraintLeave(command *ast.CreateConstraint) error {
+	log.Printf("Leave CreateConstraint\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitDropConstraintEnter(command *ast.DropConstraint) error {
+	log.Printf("Enter DropConstraint\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitDropConstraintLeave(command *ast.DropConstraint) error {
+	log.Printf("Leave DropConstraint\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitConstraintDefinitionEnter(def *ast.ConstraintDefinition) error {
+	log.Printf("Enter ConstraintDefinition\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitConstraintDefinitionLeave(def *ast.ConstraintDefinition) error {
+	log.Printf("Leave ConstraintDefinition\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitStandaloneCallEnter(call *ast.StandaloneCall) error {
+	log.Printf("Enter StandaloneCall\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitStandaloneCallLeave(call *ast.StandaloneCall) error {
+	log.Printf("Leave StandaloneCall\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitInQueryCallEnter(call *ast.InQueryCall) error {
+	log.Printf("Enter InQueryCall\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitInQueryCallLeave(call *ast.InQueryCall) error {
+	log.Printf("Leave InQueryCall\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitForeachEnter(clause *ast.ForeachClause) error {
+	log.Printf("Enter Foreach\n")
+	// The FOREACH variable, and anything bound in the body, is only visible within the body. The variable
+	// is bound when it is visited, after the list, so it is not visible in the list.
+	visitor.scopes = append(visitor.scopes, visitor.symbolTable)
+	visitor.symbolTable = copyScope(visitor.symbolTable)
+	visitor.foreachVariable = clause.Variable
+	return nil
+}
+
+func (visitor *astVisitor) VisitForeachLeave(clause *ast.ForeachClause) error {
+	log.Printf("Leave Foreach\n")
+	visitor.symbolTable = visitor.scopes[len(visitor.scopes)-1]
+	visitor.scopes = visitor.scopes[:len(visitor.scopes)-1]
+	return nil
+}
+
+func (visitor *astVisitor) VisitSubqueryCallEnter(call *ast.SubqueryCall) error {
+	log.Printf("Enter SubqueryCall\n")
+	// Only the variables imported by a leading WITH clause are visible inside the subquery. Each arm of a
+	// UNION has its own.
+	outer := visitor.symbolTable
+	visitor.scopes = append(visitor.scopes, outer)
+	visitor.symbolTable = map[string]any{}
+	arms := []ast.Query{call.Query}
+	if q, ok := call.Query.(*ast.UnionQuery); ok {
+		arms = []ast.Query{q.Query}
+		for _, union := range q.Unions {
+			arms = append(arms, union.Query)
+		}
+	}
+	for _, arm := range arms {
+		if q, ok := arm.(*ast.MultiPartQuery); ok {
+			part := q.Parts[0]
+			if len(part.ReadingClause) == 0 && len(part.UpdatingClause) == 0 {
+				visitor.importingWith[part.With] = outer
+			}
+		}
+	}
+	return nil
+}
+
+func (visitor *astVisitor) VisitSubqueryCallLeave(call *ast.SubqueryCall) error {
+	log.Printf("Leave SubqueryCall\n")
+	// The variables returned by the subquery are added to the enclosing scope.
+	visitor.symbolTable = visitor.scopes[len(visitor.scopes)-1]
+	visitor.scopes = visitor.scopes[:len(visitor.scopes)-1]
+	if columns, ok := visitor.returnColumns(call.Query); ok {
+		for _, id := range columns {
+			if _, ok := visitor.symbolTable[id]; ok {
+				return cypher.NewVariableAlreadyBoundErr(id)
+			}
+			visitor.symbolTable[id] = valueSymbol
+		}
+	}
+	return nil
+}
+
+func (visitor *astVisitor) VisitProcedureInvocationEnter(inv *ast.ProcedureInvocation) error {
+	log.Printf("Enter ProcedureInvocation\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitProcedureInvocationLeave(inv *ast.ProcedureInvocation) error {
+	log.Printf("Leave ProcedureInvocation\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitYieldItemsEnter(items *ast.YieldItems) error {
+	log.Printf("Enter YieldItems\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitYieldItemsLeave(items *ast.YieldItems) error {
+	log.Printf("Leave YieldItems\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitYieldItemEnter(item *ast.YieldItem) error {
+	log.Printf("Enter YieldItem\n")
+	id := symbolicNameString(item.Variable)
+	if _, ok := visitor.symbolTable[id]; ok {
+		return cypher.NewVariableAlreadyBoundErr(id)
+	}
+	visitor.symbolTable[id] = valueSymbol
+	return nil
+}
+
+func (visitor *astVisitor) VisitYieldItemLeave(item *ast.YieldItem) error {
+	log.Printf("Leave YieldItem\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLoadCSVEnter(clause *ast.LoadCSVClause) error {
+	log.Printf("Enter LoadCSV\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLoadCSVLeave(clause *ast.LoadCSVClause) error {
+	log.Printf("Leave LoadCSV\n")
+	id := symbolicNameString(clause.Variable)
+	if _, ok := visitor.symbolTable[id]; ok {
+		return cypher.NewVariableAlreadyBoundErr(id)
+	}
+	visitor.symbolTable[id] = valueSymbol
+	return nil
+}
+
+func (visitor *astVisitor) VisitWithEnter(clause *ast.WithClause) error {
+	log.Printf("Enter With\n")
+	if outer, ok := visitor.importingWith[clause]; ok {
+		delete(visitor.importingWith, clause)
+		items := clause.Projection.Items
+		if items.All {
+			for id, kind := range outer {
+				visitor.symbolTable[id] = kind
+			}
+		}
+		for _, item := range items.Items {
+			if id, ok := variableName(item.Expr); ok {
+				if kind, ok := outer[id]; ok {
+					visitor.symbolTable[id] = kind
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (visitor *astVisitor) VisitWithLeave(clause *ast.WithClause) error {
+	log.Printf("Leave With\n")
+	// Only the variables projected by WITH are visible after it.
+	visitor.symbolTable = visitor.projectedScope(clause.Projection.Items)
+	return nil
+}
+
+// projectedScope returns the variables visible after a projection: its aliases and the variables it projects
+// unaliased, or, for *, every variable in scope as well.
+func (visitor *astVisitor) projectedScope(items *ast.ProjectionItems) map[string]any {
+	scope := map[string]any{}
+	if items.All {
+		for id, kind := range visitor.symbolTable {
+			scope[id] = kind
+		}
+	}
+	for _, item := range items.Items {
+		id, ok := variableName(item.Expr)
+		var kind any = valueSymbol
+		if ok {
+			if k, bound := visitor.symbolTable[id]; bound {
+				kind = k
+			}
+		}
+		if item.Variable != nil {
+			id = symbolicNameString(item.Variable)
+		} else if !ok {
+			continue
+		}
+		scope[id] = kind
+	}
+	return scope
+}
+
 func (visitor *astVisitor) VisitPatternEnter(pattern *ast.Pattern) error {
 	log.Printf("Enter Pattern\n")
 	visitor.inPattern = true
+	scope := map[string]any{}
+	for id, kind := range visitor.symbolTable {
+		scope[id] = kind
+	}
+	visitor.patternScopes = append(visitor.patternScopes, scope)
 	return nil
 }
 
 func (visitor *astVisitor) VisitPatternLeave(pattern *ast.Pattern) error {
 	log.Printf("Leave Pattern\n")
 	visitor.inPattern = false
+	visitor.patternScopes = visitor.patternScopes[:len(visitor.patternScopes)-1]
 	return nil
 }
 
+// checkElementPredicate checks that the inline WHERE of a node or relationship pattern refers only to the
+// element's own variable and to variables bound before the pattern.
+func (visitor *astVisitor) checkElementPredicate(variable ast.SymbolicName, kind symbolKind, predicate ast.Expr) error {
+	if predicate == nil {
+		return nil
+	}
+	scope := visitor.symbolTable
+	if len(visitor.patternScopes) > 0 {
+		scope = visitor.patternScopes[len(visitor.patternScopes)-1]
+	}
+	symbols := map[string]any{}
+	for id, symbol := range scope {
+		symbols[id] = symbol
+	}
+	if variable != nil {
+		symbols[symbolicNameString(variable)] = kind
+	}
+	return predicate.Accept(&astVisitor{symbolTable: symbols})
+}
+
 func (visitor *astVisitor) VisitPatternPartEnter(part *ast.PatternPart) error {
 	log.Printf("Enter PatternPart\n")
+	if part.Variable != nil {
+		id := symbolicNameString(part.Variable)
+		if _, ok := visitor.symbolTable[id]; ok {
+			return cypher.NewVariableAlreadyBoundErr(id)
+		}
+		visitor.symbolTable[id] = pathSymbol
+	}
 	return nil
 }
 
@@ -100,8 +690,21 @@ func (visitor *astVisitor) VisitPatternPartLeave(part *ast.PatternPart) error {
 	return nil
 }
 
+func (visitor *astVisitor) VisitShortestPathPatternEnter(part *ast.ShortestPathPattern) error {
+	log.Printf("Enter ShortestPathPattern\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitShortestPathPatternLeave(part *ast.ShortestPathPattern) error {
+	log.Printf("Leave ShortestPathPattern\n")
+	return nil
+}
+
 func (visitor *astVisitor) VisitPatternElementNestedEnter(part *ast.PatternElementNested) error {
 	log.Printf("Enter PatternElementNested\n")
+	if (visitor.inCreate || visitor.inMerge) && part.Quantifier != nil {
+		return cypher.NewCreatingVarLength()
+	}
 	return nil
 }
 
@@ -110,9 +713,24 @@ func (visitor *astVisitor) VisitPatternElementNestedLeave(part *ast.PatternEleme
 	return nil
 }
 
+func (visitor *astVisitor) VisitPatternElementSequenceEnter(part *ast.PatternElementSequence) error {
+	log.Printf("Enter PatternElementSequence\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPatternElementSequenceLeave(part *ast.PatternElementSequence) error {
+	log.Printf("Leave PatternElementSequence\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPathSelector(selector *ast.PathSelector) error {
+	log.Printf("PathSelector\n")
+	return nil
+}
+
 func (visitor *astVisitor) VisitPatternElementPatternEnter(part *ast.PatternElementPattern) error {
 	log.Printf("Enter PatternElementPattern\n")
-	if visitor.inCreate && len(part.Chain) == 0 {
+	if (visitor.inCreate || visitor.inMerge) && len(part.Chain) == 0 {
 		visitor.creatingNode = true
 	}
 	return nil
@@ -161,6 +779,9 @@ func (visitor *astVisitor) VisitProjectionItemEnter(item *ast.ProjectionItem) er
 
 func (visitor *astVisitor) VisitProjectionItemLeave(item *ast.ProjectionItem) error {
 	log.Printf("Leave ProjectionItem\n")
+	if item.Variable != nil {
+		visitor.getOrBind(symbolicNameString(item.Variable), valueSymbol)
+	}
 	return nil
 }
 
@@ -241,11 +862,13 @@ func (visitor *astVisitor) VisitPropertyLabelsExprLeave(expr *ast.PropertyLabels
 
 func (visitor *astVisitor) VisitSymbolicNameSchemaNameEnter(name *ast.SymbolicNameSchemaName) error {
 	log.Printf("Enter SymbolicNameSchemaName\n")
+	visitor.inSchemaName = true
 	return nil
 }
 
 func (visitor *astVisitor) VisitSymbolicNameSchemaNameLeave(name *ast.SymbolicNameSchemaName) error {
 	log.Printf("Leave SymbolicNameSchemaName\n")
+	visitor.inSchemaName = false
 	return nil
 }
 
@@ -256,18 +879,36 @@ func (visitor *astVisitor) VisitReservedWordSchemaName(name *ast.ReservedWordSch
 
 func (visitor *astVisitor) VisitSymbolicNameIdentifier(name *ast.SymbolicNameIdentifier) error {
 	log.Printf("SymbolicNameIdentifier\n")
+	if visitor.bindForeachVariable(name) {
+		return nil
+	}
 	return visitor.visitSymbolicName(name.Identifier.Lexeme)
 }
 
 func (visitor *astVisitor) VisitSymbolicNameHexLetter(name *ast.SymbolicNameHexLetter) error {
 	log.Printf("SymbolicNameHexLetter\n")
+	if visitor.bindForeachVariable(name) {
+		return nil
+	}
 	builder := strings.Builder{}
 	builder.WriteRune(name.Letter)
 	return visitor.visitSymbolicName(builder.String())
 }
 
+// bindForeachVariable binds name if it is the variable of the FOREACH clause being visited.
+func (visitor *astVisitor) bindForeachVariable(name ast.SymbolicName) bool {
+	if visitor.foreachVariable == nil || name != visitor.foreachVariable {
+		return false
+	}
+	visitor.symbolTable[symbolicNameString(name)] = valueSymbol
+	visitor.foreachVariable = nil
+	return true
+}
+
 func (visitor *astVisitor) visitSymbolicName(id string) error {
 	switch {
+	case visitor.inSchemaName:
+		// Labels, relationship types and property keys are not variables.
 	case visitor.inExpr:
 		if _, ok := visitor.symbolTable[id]; !ok {
 			return cypher.NewUndefinedVariableErr(id)
@@ -276,30 +917,30 @@ func (visitor *astVisitor) visitSymbolicName(id string) error {
 		if _, ok := visitor.symbolTable[id]; ok {
 			return cypher.NewVariableAlreadyBoundErr(id)
 		}
-		visitor.symbolTable[id] = true
+		visitor.symbolTable[id] = relationshipSymbol
 	case visitor.inNode:
 		if visitor.creatingNode {
 			if _, ok := visitor.symbolTable[id]; ok {
 				return cypher.NewVariableAlreadyBoundErr(id)
 			}
-			visitor.symbolTable[id] = true
-		} else if visitor.inCreate {
+			visitor.symbolTable[id] = nodeSymbol
+		} else if visitor.inCreate || visitor.inMerge {
 			if visitor.hasProps || visitor.hasLabels {
 				if _, ok := visitor.symbolTable[id]; ok {
 					return cypher.NewVariableAlreadyBoundErr(id)
 				}
 			}
 		}
-		visitor.getOrBind(id)
+		visitor.getOrBind(id, nodeSymbol)
 	}
 	return nil
 }
 
-func (visitor *astVisitor) getOrBind(id string) {
+func (visitor *astVisitor) getOrBind(id string, kind symbolKind) {
 	if _, ok := visitor.symbolTable[id]; ok {
 		return
 	}
-	visitor.symbolTable[id] = true
+	visitor.symbolTable[id] = kind
 }
 
 func (visitor *astVisitor) VisitReservedWord(word *ast.ReservedWord) error {
@@ -402,10 +1043,10 @@ func (visitor *astVisitor) VisitPatternComprehensionExprLeave(expr *ast.PatternC
 func (visitor *astVisitor) VisitNodePatternEnter(pattern *ast.NodePattern) error {
 	log.Printf("Enter NodePattern\n")
 	visitor.inNode = true
-	if len(pattern.Labels) > 0 {
+	if len(pattern.Labels) > 0 || pattern.LabelExpr != nil {
 		visitor.hasLabels = true
 	}
-	return nil
+	return visitor.checkElementPredicate(pattern.Variable, nodeSymbol, pattern.WhereExpr)
 }
 
 func (visitor *astVisitor) VisitNodePatternLeave(pattern *ast.NodePattern) error {
@@ -437,7 +1078,10 @@ func (visitor *astVisitor) VisitPropertyKeyNameLeave(name *ast.PropertyKeyName)
 
 func (visitor *astVisitor) VisitPropertiesEnter(props *ast.Properties) error {
 	log.Printf("Enter Properties\n")
-	if len(props.MapLiteral.PropertyKeyNames) > 0 {
+	if props.Parameter != nil && visitor.inMerge {
+		return cypher.NewInvalidParameterUse()
+	}
+	if props.MapLiteral != nil && len(props.MapLiteral.PropertyKeyNames) > 0 {
 		visitor.hasProps = true
 	}
 	return nil
@@ -483,6 +1127,9 @@ func (visitor *astVisitor) VisitRelationshipPatternEnter(pattern *ast.Relationsh
 			return cypher.NewNoSingleRelationshipType()
 		}
 	}
+	if visitor.inMerge && pattern.RelationshipDetail == nil {
+		return cypher.NewNoSingleRelationshipType()
+	}
 	return nil
 }
 
@@ -495,17 +1142,17 @@ func (visitor *astVisitor) VisitRelationshipPatternLeave(pattern *ast.Relationsh
 func (visitor *astVisitor) VisitRelationshipDetailEnter(detail *ast.RelationshipDetail) error {
 	log.Printf("Enter RelationshipDetail\n")
 	visitor.inPattern = true
-	if visitor.inCreate {
+	if visitor.inCreate || visitor.inMerge {
 		if detail.RangeLiteral != nil {
 			return cypher.NewCreatingVarLength()
 		}
 	}
-	return nil
+	return visitor.checkElementPredicate(detail.Variable, relationshipSymbol, detail.WhereExpr)
 }
 
 func (visitor *astVisitor) VisitRelationshipDetailLeave(detail *ast.RelationshipDetail) error {
 	log.Printf("Leave RelationshipDetail\n")
-	if visitor.inCreate {
+	if visitor.inCreate || visitor.inMerge {
 		if len(detail.RelationshipTypes) != 1 {
 			return cypher.NewNoSingleRelationshipType()
 		}
@@ -521,6 +1168,16 @@ func (visitor *astVisitor) VisitRangeLiteral(literal *ast.RangeLiteral) error {
 
 func (visitor *astVisitor) VisitFunctionInvocationEnter(fnc *ast.FunctionInvocation) error {
 	log.Printf("Enter FunctionInvocation\n")
+	if name, ok := fnc.FunctionName.(*ast.SymbolicFunctionName); ok && len(name.Namespace) == 0 && len(fnc.Args) == 1 {
+		if strings.ToLower(symbolicNameString(name.FunctionName)) == "length" {
+			if id, ok := variableName(fnc.Args[0]); ok {
+				switch visitor.symbolTable[id] {
+				case nodeSymbol, relationshipSymbol:
+					return cypher.NewInvalidArgumentType()
+				}
+			}
+		}
+	}
 	return nil
 }
 
@@ -549,6 +1206,199 @@ func (visitor *astVisitor) VisitListOperatorExprLeave(expr *ast.ListOperatorExpr
 	return nil
 }
 
+func (visitor *astVisitor) VisitMapProjectionExprEnter(expr *ast.MapProjectionExpr) error {
+	log.Printf("Enter MapProjectionExpr\n")
+	return visitor.expectBound(expr.Variable)
+}
+
+func (visitor *astVisitor) VisitMapProjectionExprLeave(expr *ast.MapProjectionExpr) error {
+	log.Printf("Leave MapProjectionExpr\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertySelectorEnter(selector *ast.PropertySelector) error {
+	log.Printf("Enter PropertySelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitPropertySelectorLeave(selector *ast.PropertySelector) error {
+	log.Printf("Leave PropertySelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitAllPropertiesSelector(selector *ast.AllPropertiesSelector) error {
+	log.Printf("AllPropertiesSelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLiteralEntrySelectorEnter(selector *ast.LiteralEntrySelector) error {
+	log.Printf("Enter LiteralEntrySelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLiteralEntrySelectorLeave(selector *ast.LiteralEntrySelector) error {
+	log.Printf("Leave LiteralEntrySelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitVariableSelectorEnter(selector *ast.VariableSelector) error {
+	log.Printf("Enter VariableSelector\n")
+	return visitor.expectBound(selector.Variable)
+}
+
+func (visitor *astVisitor) VisitVariableSelectorLeave(selector *ast.VariableSelector) error {
+	log.Printf("Leave VariableSelector\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitTypePredicateExprEnter(expr *ast.TypePredicateExpr) error {
+	log.Printf("Enter TypePredicateExpr\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitTypePredicateExprLeave(expr *ast.TypePredicateExpr) error {
+	log.Printf("Leave TypePredicateExpr\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitSimpleType(t *ast.SimpleType) error {
+	log.Printf("SimpleType\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitListTypeEnter(t *ast.ListType) error {
+	log.Printf("Enter ListType\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitListTypeLeave(t *ast.ListType) error {
+	log.Printf("Leave ListType\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnionTypeEnter(t *ast.UnionType) error {
+	log.Printf("Enter UnionType\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitUnionTypeLeave(t *ast.UnionType) error {
+	log.Printf("Leave UnionType\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelNameEnter(expr *ast.LabelName) error {
+	log.Printf("Enter LabelName\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelNameLeave(expr *ast.LabelName) error {
+	log.Printf("Leave LabelName\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelWildcard(expr *ast.LabelWildcard) error {
+	log.Printf("LabelWildcard\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelNotEnter(expr *ast.LabelNot) error {
+	log.Printf("Enter LabelNot\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelNotLeave(expr *ast.LabelNot) error {
+	log.Printf("Leave LabelNot\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelAndEnter(expr *ast.LabelAnd) error {
+	log.Printf("Enter LabelAnd\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelAndLeave(expr *ast.LabelAnd) error {
+	log.Printf("Leave LabelAnd\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelOrEnter(expr *ast.LabelOr) error {
+	log.Printf("Enter LabelOr\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitLabelOrLeave(expr *ast.LabelOr) error {
+	log.Printf("Leave LabelOr\n")
+	return nil
+}
+
+func (visitor *astVisitor) VisitExistsSubqueryExprEnter(expr *ast.ExistsSubqueryExpr) error {
+	log.Printf("Enter ExistsSubqueryExpr\n")
+	if expr.Query != nil && hasUpdatingClause(expr.Query) {
+		return cypher.NewInvalidClauseComposition()
+	}
+	visitor.enterSubqueryExpr()
+	return nil
+}
+
+func (visitor *astVisitor) VisitExistsSubqueryExprLeave(expr *ast.ExistsSubqueryExpr) error {
+	log.Printf("Leave ExistsSubqueryExpr\n")
+	visitor.leaveSubqueryExpr()
+	return nil
+}
+
+func (visitor *astVisitor) VisitCountSubqueryExprEnter(expr *ast.CountSubqueryExpr) error {
+	log.Printf("Enter CountSubqueryExpr\n")
+	if expr.Query != nil && hasUpdatingClause(expr.Query) {
+		return cypher.NewInvalidClauseComposition()
+	}
+	visitor.enterSubqueryExpr()
+	return nil
+}
+
+func (visitor *astVisitor) VisitCountSubqueryExprLeave(expr *ast.CountSubqueryExpr) error {
+	log.Printf("Leave CountSubqueryExpr\n")
+	visitor.leaveSubqueryExpr()
+	return nil
+}
+
+// enterSubqueryExpr enters the scope of an EXISTS or COUNT subquery, which sees the variables of the enclosing
+// scope, but whose own variables are not visible outside it.
+func (visitor *astVisitor) enterSubqueryExpr() {
+	visitor.scopes = append(visitor.scopes, visitor.symbolTable)
+	visitor.symbolTable = copyScope(visitor.symbolTable)
+}
+
+func (visitor *astVisitor) leaveSubqueryExpr() {
+	visitor.symbolTable = visitor.scopes[len(visitor.scopes)-1]
+	visitor.scopes = visitor.scopes[:len(visitor.scopes)-1]
+}
+
+// hasUpdatingClause reports whether any part of query updates the graph, which is not allowed in a subquery
+// expression.
+func hasUpdatingClause(query ast.Query) bool {
+	switch q := query.(type) {
+	case *ast.SinglePartQuery:
+		return len(q.UpdatingClause) > 0
+	case *ast.MultiPartQuery:
+		for _, part := range q.Parts {
+			if len(part.UpdatingClause) > 0 {
+				return true
+			}
+		}
+		return hasUpdatingClause(q.SinglePartQuery)
+	case *ast.UnionQuery:
+		if hasUpdatingClause(q.Query) {
+			return true
+		}
+		for _, union := range q.Unions {
+			if hasUpdatingClause(union.Query) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (visitor *astVisitor) VisitExistsFunctionName(name *ast.ExistsFunctionName) error {
 	log.Printf("ExistsFunctionName\n")
 	return nil
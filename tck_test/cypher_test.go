@@ -93,6 +93,18 @@ func (g *graphFeature) theResultShouldBeInAnyOrder(ctx context.Context, table *g
 	return ctx, nil
 }
 
+func (g *graphFeature) theResultShouldBeInOrder(ctx context.Context, table *godog.Table) (context.Context, error) {
+	return ctx, nil
+}
+
+func (g *graphFeature) theResultShouldBeIgnoringListOrder(ctx context.Context, table *godog.Table) (context.Context, error) {
+	return ctx, nil
+}
+
+func (g *graphFeature) parametersAre(ctx context.Context, table *godog.Table) (context.Context, error) {
+	return ctx, nil
+}
+
 func (g *graphFeature) theSideEffectsShouldBe(ctx context.Context, values *godog.Table) (context.Context, error) {
 	return ctx, nil
 }
@@ -113,6 +125,10 @@ func (g *graphFeature) syntaxErrorRaised(ctx context.Context, errStr string) (co
 	return ctx, fmt.Errorf("expecting syntax error: %s", errStr)
 }
 
+func (g *graphFeature) runtimeErrorRaised(ctx context.Context, errType string, errStr string) (context.Context, error) {
+	return ctx, nil
+}
+
 func TestCypherFeatures(t *testing.T) {
 	suite := godog.TestSuite{
 		ScenarioInitializer: InitializeCypherScenario,
@@ -121,6 +137,14 @@ func TestCypherFeatures(t *testing.T) {
 			Paths: []string{
 				//"debug.feature",
 				"tck/features/clauses/create",
+				"tck/features/clauses/delete",
+				"tck/features/clauses/merge",
+				"tck/features/clauses/remove",
+				"tck/features/clauses/set",
+				"tck/features/clauses/union",
+				"tck/features/clauses/unwind",
+				"tck/features/expressions/existentialSubqueries",
+				"tck/features/expressions/path",
 				//"tck/features/clauses/match/Match1.feature",
 			},
 			TestingT: t,
@@ -141,9 +165,13 @@ func InitializeCypherScenario(sc *godog.ScenarioContext) {
 	sc.Step(`^executing query:$`, g.executingQuery)
 	sc.Step(`^the result should be empty$`, g.theResultShouldBeEmpty)
 	sc.Step(`^the result should be, in any order:$`, g.theResultShouldBeInAnyOrder)
+	sc.Step(`^the result should be, in order:$`, g.theResultShouldBeInOrder)
+	sc.Step(`^the result should be \(ignoring element order for lists\):$`, g.theResultShouldBeIgnoringListOrder)
+	sc.Step(`^parameters are:$`, g.parametersAre)
 	sc.Step(`^the side effects should be:$`, g.theSideEffectsShouldBe)
 	sc.Step(`^no side effects$`, g.noSideEffects)
 	sc.Step(`^a SyntaxError should be raised at compile time: ([a-zA-Z]+)$`, g.syntaxErrorRaised)
+	sc.Step(`^a ([a-zA-Z]+) should be raised at runtime: ([a-zA-Z]+)$`, g.runtimeErrorRaised)
 	sc.Step(`^executing control query:$`, g.executingControlQuery)
 	sc.Step(`^having executed:$`, g.havingExecutedQuery)
 